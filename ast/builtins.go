@@ -0,0 +1,125 @@
+package ast
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BuiltinFunction is a native Go function exposed to fredlang programs
+// under a fixed name, invoked through CallExpression the same way as a
+// user-defined Closure.
+type BuiltinFunction func(args []any) (any, error)
+
+// stdin is shared across every builtinInput call. bufio.Reader reads
+// ahead into its own internal buffer, so allocating a fresh one per call
+// would silently discard whatever the previous call had already buffered
+// past the line it returned.
+var stdin = bufio.NewReader(os.Stdin)
+
+// builtins holds the functions bound by NewGlobalEnvironment. "print" is
+// deliberately absent: the lexer always tokenizes that lexeme as the
+// lexer.Print keyword, so a callable builtin under the same name could
+// never be reached through CallExpression.
+var builtins = map[string]BuiltinFunction{
+	"len":   builtinLen,
+	"str":   builtinStr,
+	"num":   builtinNum,
+	"input": builtinInput,
+	"type":  builtinType,
+}
+
+func builtinLen(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("len expects 1 argument, got %d", len(args))
+	}
+
+	str, ok := args[0].(string)
+
+	if !ok {
+		return nil, fmt.Errorf("len expects a string, got %T", args[0])
+	}
+
+	return int64(len(str)), nil
+}
+
+func builtinStr(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("str expects 1 argument, got %d", len(args))
+	}
+
+	return fmt.Sprintf("%v", args[0]), nil
+}
+
+func builtinNum(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("num expects 1 argument, got %d", len(args))
+	}
+
+	str, ok := args[0].(string)
+
+	if !ok {
+		return nil, fmt.Errorf("num expects a string, got %T", args[0])
+	}
+
+	if integer, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return integer, nil
+	}
+
+	float, err := strconv.ParseFloat(str, 64)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %q to a number", str)
+	}
+
+	return float, nil
+}
+
+func builtinInput(args []any) (any, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("input expects at most 1 argument, got %d", len(args))
+	}
+
+	if len(args) == 1 {
+		prompt, ok := args[0].(string)
+
+		if !ok {
+			return nil, fmt.Errorf("input expects a string prompt, got %T", args[0])
+		}
+
+		fmt.Print(prompt)
+	}
+
+	line, err := stdin.ReadString('\n')
+
+	if err != nil && line == "" {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func builtinType(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("type expects 1 argument, got %d", len(args))
+	}
+
+	switch args[0].(type) {
+	case int64:
+		return "int", nil
+	case float64:
+		return "float", nil
+	case string:
+		return "string", nil
+	case bool:
+		return "bool", nil
+	case nil:
+		return "null", nil
+	case Closure, BuiltinFunction:
+		return "function", nil
+	default:
+		return "unknown", nil
+	}
+}
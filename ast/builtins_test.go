@@ -0,0 +1,89 @@
+package ast
+
+import (
+	"bufio"
+	"os"
+	"testing"
+)
+
+func TestBuiltinLen(t *testing.T) {
+	value, err := builtinLen([]any{"hello"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if value != int64(5) {
+		t.Errorf("expected len to return 5, got %#v", value)
+	}
+
+	if _, err := builtinLen([]any{10}); err == nil {
+		t.Error("expected an error calling len with a non-string argument")
+	}
+}
+
+func TestBuiltinType(t *testing.T) {
+	cases := []struct {
+		arg      any
+		expected string
+	}{
+		{int64(1), "int"},
+		{1.5, "float"},
+		{"str", "string"},
+		{true, "bool"},
+		{nil, "null"},
+	}
+
+	for _, c := range cases {
+		value, err := builtinType([]any{c.arg})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if value != c.expected {
+			t.Errorf("expected type(%#v) to return %q, got %#v", c.arg, c.expected, value)
+		}
+	}
+}
+
+// TestBuiltinInputReusesReader proves input() shares a single buffered
+// reader across calls: a fresh bufio.Reader per call would read ahead
+// past the first line and swallow the second one.
+func TestBuiltinInputReusesReader(t *testing.T) {
+	r, w, err := os.Pipe()
+
+	if err != nil {
+		t.Fatalf("failed to create pipe: %s", err)
+	}
+	defer r.Close()
+
+	originalStdin := stdin
+	stdin = bufio.NewReader(r)
+	defer func() { stdin = originalStdin }()
+
+	if _, err := w.WriteString("first\nsecond\n"); err != nil {
+		t.Fatalf("failed to write to pipe: %s", err)
+	}
+	w.Close()
+
+	first, err := builtinInput(nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error on first call: %s", err)
+	}
+
+	if first != "first" {
+		t.Errorf("expected the first call to return %q, got %#v", "first", first)
+	}
+
+	second, err := builtinInput(nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error on second call: %s", err)
+	}
+
+	if second != "second" {
+		t.Errorf("expected the second call to return %q, got %#v", "second", second)
+	}
+}
@@ -0,0 +1,69 @@
+package ast
+
+import "fmt"
+
+// Environment holds variable and function bindings for a lexical scope,
+// chaining to an optional outer scope so inner blocks, loops and
+// functions can read (and shadow) bindings from the scopes around them.
+type Environment struct {
+	values map[string]any
+	outer  *Environment
+}
+
+// NewEnvironment creates an empty, top-level environment.
+func NewEnvironment() *Environment {
+	return &Environment{values: map[string]any{}}
+}
+
+// NewEnclosedEnvironment creates a child environment nested inside outer.
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	return &Environment{values: map[string]any{}, outer: outer}
+}
+
+// NewGlobalEnvironment creates a top-level environment with the builtin
+// functions (len, str, num, input, type) already bound.
+func NewGlobalEnvironment() *Environment {
+	env := NewEnvironment()
+
+	for name, fn := range builtins {
+		env.Define(name, fn)
+	}
+
+	return env
+}
+
+// Define binds name to value in the current scope, shadowing any binding
+// with the same name from an outer scope.
+func (e *Environment) Define(name string, value any) {
+	e.values[name] = value
+}
+
+// Get looks up name in the current scope, walking outward through
+// enclosing scopes until it is found.
+func (e *Environment) Get(name string) (any, bool) {
+	if value, ok := e.values[name]; ok {
+		return value, true
+	}
+
+	if e.outer != nil {
+		return e.outer.Get(name)
+	}
+
+	return nil, false
+}
+
+// Assign updates an already-declared binding, walking outward through
+// enclosing scopes. It fails if name was never defined anywhere in the
+// chain, since fredlang has no implicit globals.
+func (e *Environment) Assign(name string, value any) error {
+	if _, ok := e.values[name]; ok {
+		e.values[name] = value
+		return nil
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(name, value)
+	}
+
+	return fmt.Errorf("undefined variable %q", name)
+}
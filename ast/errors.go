@@ -0,0 +1,51 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+// SyntaxError is a parse-time error tied to the token that triggered it.
+type SyntaxError struct {
+	Pos     lexer.Position
+	Message string
+}
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// WithSource renders the error together with the offending source line
+// and a caret underline, for front ends (like the REPL) that still have
+// the original source text at hand.
+func (e SyntaxError) WithSource(source string) string {
+	return withSource(e.Pos, e.Message, source)
+}
+
+// RuntimeError is an evaluation-time error tied to the node that raised it.
+type RuntimeError struct {
+	Pos     lexer.Position
+	Message string
+}
+
+func (e RuntimeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Message)
+}
+
+// WithSource renders the error together with the offending source line
+// and a caret underline, for front ends (like the REPL) that still have
+// the original source text at hand.
+func (e RuntimeError) WithSource(source string) string {
+	return withSource(e.Pos, e.Message, source)
+}
+
+func withSource(pos lexer.Position, message, source string) string {
+	underline := pos.Underline(source)
+
+	if underline == "" {
+		return fmt.Sprintf("%s: %s", pos, message)
+	}
+
+	return fmt.Sprintf("%s: %s\n%s", pos, message, underline)
+}
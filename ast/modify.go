@@ -0,0 +1,123 @@
+package ast
+
+import "fmt"
+
+// ModifierFunc transforms a single node, returning its replacement. It is
+// applied bottom-up by Modify: children are rewritten first, so a
+// ModifierFunc only ever sees already-modified subtrees.
+type ModifierFunc func(Node) Node
+
+// Modify recursively rewrites node's children with m, then calls m on
+// node itself and returns the result. It exists to back upcoming
+// source-to-source transforms such as constant folding or quote/unquote
+// macro expansion, which need to replace nodes deep inside a tree
+// without hand-rolling a traversal for every pass.
+//
+// Modify panics on a Node type it doesn't know how to walk, since that
+// means the ast package grew a node kind without updating Modify to
+// match.
+func Modify(node Node, m ModifierFunc) Node {
+	switch n := node.(type) {
+	case Program:
+		for i, stmt := range n.Statements {
+			n.Statements[i] = Modify(stmt, m)
+		}
+
+		node = n
+	case Primary:
+		if nested, ok := n.value.(Node); ok {
+			n.value = Modify(nested, m)
+		}
+
+		node = n
+	case Identifier:
+		// no children to descend into
+	case CallExpression:
+		n.callee = Modify(n.callee, m)
+
+		for i, arg := range n.args {
+			n.args[i] = Modify(arg, m)
+		}
+
+		node = n
+	case Unary:
+		n.node = Modify(n.node, m)
+		node = n
+	case Binary:
+		n.left = Modify(n.left, m)
+		n.right = Modify(n.right, m)
+		node = n
+	case Ternary:
+		n.cond = Modify(n.cond, m)
+		n.then = Modify(n.then, m)
+		n.els = Modify(n.els, m)
+		node = n
+	case VarDecl:
+		n.Value = Modify(n.Value, m)
+		node = n
+	case Assign:
+		n.Value = Modify(n.Value, m)
+		node = n
+	case ExprStmt:
+		n.Expr = Modify(n.Expr, m)
+		node = n
+	case PrintStmt:
+		n.Expr = Modify(n.Expr, m)
+		node = n
+	case Block:
+		for i, stmt := range n.Statements {
+			n.Statements[i] = Modify(stmt, m)
+		}
+
+		node = n
+	case IfStmt:
+		n.Cond = Modify(n.Cond, m)
+		n.Then = Modify(n.Then, m).(Block)
+
+		for i, branch := range n.ElseIfs {
+			branch.Cond = Modify(branch.Cond, m)
+			branch.Body = Modify(branch.Body, m).(Block)
+			n.ElseIfs[i] = branch
+		}
+
+		if n.Else != nil {
+			n.Else = Modify(n.Else, m)
+		}
+
+		node = n
+	case WhileStmt:
+		n.Cond = Modify(n.Cond, m)
+		n.Body = Modify(n.Body, m).(Block)
+		node = n
+	case ForStmt:
+		if n.Init != nil {
+			n.Init = Modify(n.Init, m)
+		}
+
+		if n.Cond != nil {
+			n.Cond = Modify(n.Cond, m)
+		}
+
+		if n.Post != nil {
+			n.Post = Modify(n.Post, m)
+		}
+
+		n.Body = Modify(n.Body, m).(Block)
+		node = n
+	case FunctionDecl:
+		n.Body = Modify(n.Body, m).(Block)
+		node = n
+	case ReturnStmt:
+		if n.Value != nil {
+			n.Value = Modify(n.Value, m)
+		}
+
+		node = n
+	case Break:
+		// no children to descend into
+	default:
+		panic(fmt.Sprintf("ast.Modify: unsupported node type %T", node))
+	}
+
+	return m(node)
+}
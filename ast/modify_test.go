@@ -0,0 +1,130 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+// doubleIntegers is a ModifierFunc that doubles every integer literal it
+// sees, leaving every other node untouched.
+func doubleIntegers(n Node) Node {
+	primary, ok := n.(Primary)
+
+	if !ok {
+		return n
+	}
+
+	number, ok := primary.value.(int64)
+
+	if !ok {
+		return n
+	}
+
+	return Primary{number * 2}
+}
+
+func TestModifyDoublesIntegersAcrossTree(t *testing.T) {
+	one := Primary{int64(1)}
+	two := Primary{int64(2)}
+
+	program := Program{
+		Statements: []Node{
+			VarDecl{Name: "x", Value: Binary{one, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), two}},
+			ExprStmt{Expr: Unary{lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1), one}},
+			PrintStmt{Expr: one},
+			IfStmt{
+				Cond: one,
+				Then: Block{Statements: []Node{ExprStmt{Expr: one}}},
+				ElseIfs: []ElseIfBranch{
+					{Cond: one, Body: Block{Statements: []Node{ExprStmt{Expr: one}}}},
+				},
+				Else: Block{Statements: []Node{ExprStmt{Expr: one}}},
+			},
+			FunctionDecl{
+				Name: "f",
+				Body: Block{Statements: []Node{ReturnStmt{Value: one}}},
+			},
+			Break{},
+		},
+	}
+
+	modified := Modify(program, doubleIntegers).(Program)
+
+	varDecl := modified.Statements[0].(VarDecl)
+	binary := varDecl.Value.(Binary)
+
+	if binary.left != (Primary{int64(2)}) || binary.right != (Primary{int64(4)}) {
+		t.Errorf("expected the binary operands to be doubled, got %#v", binary)
+	}
+
+	unaryStmt := modified.Statements[1].(ExprStmt)
+	unary := unaryStmt.Expr.(Unary)
+
+	if unary.node != (Primary{int64(2)}) {
+		t.Errorf("expected the unary operand to be doubled, got %#v", unary.node)
+	}
+
+	printStmt := modified.Statements[2].(PrintStmt)
+
+	if printStmt.Expr != (Primary{int64(2)}) {
+		t.Errorf("expected the print expression to be doubled, got %#v", printStmt.Expr)
+	}
+
+	ifStmt := modified.Statements[3].(IfStmt)
+
+	if ifStmt.Cond != (Primary{int64(2)}) {
+		t.Errorf("expected the if condition to be doubled, got %#v", ifStmt.Cond)
+	}
+
+	if ifStmt.Then.Statements[0].(ExprStmt).Expr != (Primary{int64(2)}) {
+		t.Errorf("expected the then branch to be doubled, got %#v", ifStmt.Then)
+	}
+
+	if ifStmt.ElseIfs[0].Cond != (Primary{int64(2)}) {
+		t.Errorf("expected the elseif condition to be doubled, got %#v", ifStmt.ElseIfs[0].Cond)
+	}
+
+	if ifStmt.ElseIfs[0].Body.Statements[0].(ExprStmt).Expr != (Primary{int64(2)}) {
+		t.Errorf("expected the elseif body to be doubled, got %#v", ifStmt.ElseIfs[0].Body)
+	}
+
+	if ifStmt.Else.(Block).Statements[0].(ExprStmt).Expr != (Primary{int64(2)}) {
+		t.Errorf("expected the else branch to be doubled, got %#v", ifStmt.Else)
+	}
+
+	functionDecl := modified.Statements[4].(FunctionDecl)
+	returnStmt := functionDecl.Body.Statements[0].(ReturnStmt)
+
+	if returnStmt.Value != (Primary{int64(2)}) {
+		t.Errorf("expected the return value to be doubled, got %#v", returnStmt.Value)
+	}
+}
+
+func TestModifyDescendsGroupedPrimary(t *testing.T) {
+	grouped := Primary{Primary{int64(21)}}
+
+	modified := Modify(grouped, doubleIntegers).(Primary)
+	inner := modified.value.(Primary)
+
+	if inner != (Primary{int64(42)}) {
+		t.Errorf("expected the grouped primary to be doubled, got %#v", inner)
+	}
+}
+
+func TestModifyPanicsOnUnknownNodeType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Modify to panic on an unsupported node type")
+		}
+	}()
+
+	Modify(unknownNode{}, doubleIntegers)
+}
+
+// unknownNode is a Node implementation the ast package doesn't know
+// about, used to exercise Modify's default panic case.
+type unknownNode struct{}
+
+func (unknownNode) String() string                 { return "unknown" }
+func (unknownNode) Eval(*Environment) (any, error) { return nil, nil }
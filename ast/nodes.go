@@ -3,13 +3,14 @@ package ast
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/alfredoprograma/fredlang/lexer"
 )
 
 type Node interface {
 	String() string
-	Eval() (any, error)
+	Eval(env *Environment) (any, error)
 }
 
 type Primary struct {
@@ -28,11 +29,11 @@ func (p Primary) String() string {
 	}
 }
 
-func (p Primary) Eval() (any, error) {
+func (p Primary) Eval(env *Environment) (any, error) {
 	switch p.value.(type) {
 	case Node:
 		node := p.value.(Node)
-		return node.Eval()
+		return node.Eval(env)
 	default:
 		// Strings, integers, floats, booleans and nil primitive values are
 		// directly extracted
@@ -40,6 +41,149 @@ func (p Primary) Eval() (any, error) {
 	}
 }
 
+// Value returns the wrapped literal, or the grouped Node for a
+// parenthesized expression.
+func (p Primary) Value() any {
+	return p.value
+}
+
+// Identifier is a reference to a variable or function binding, resolved
+// against the Environment at evaluation time.
+type Identifier struct {
+	name string
+	pos  lexer.Position
+}
+
+func (i Identifier) String() string {
+	return i.name
+}
+
+func (i Identifier) Eval(env *Environment) (any, error) {
+	value, ok := env.Get(i.name)
+
+	if !ok {
+		return nil, RuntimeError{Pos: i.pos, Message: fmt.Sprintf("undefined variable %q", i.name)}
+	}
+
+	return value, nil
+}
+
+// Pos returns the position of the identifier in the source.
+func (i Identifier) Pos() lexer.Position {
+	return i.pos
+}
+
+// CallExpression invokes callee, a Closure or BuiltinFunction bound in the
+// Environment, with the evaluated args: `callee(args...)`.
+type CallExpression struct {
+	callee Node
+	args   []Node
+	pos    lexer.Position
+}
+
+func (c CallExpression) String() string {
+	args := make([]string, len(c.args))
+
+	for i, arg := range c.args {
+		args[i] = arg.String()
+	}
+
+	return fmt.Sprintf("%s(%s)", c.callee.String(), strings.Join(args, ", "))
+}
+
+// Pos returns the position of the call's opening parenthesis.
+func (c CallExpression) Pos() lexer.Position {
+	return c.pos
+}
+
+func (c CallExpression) Eval(env *Environment) (any, error) {
+	callee, err := c.callee.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]any, len(c.args))
+
+	for i, arg := range c.args {
+		value, err := arg.Eval(env)
+
+		if err != nil {
+			return nil, err
+		}
+
+		args[i] = value
+	}
+
+	switch fn := callee.(type) {
+	case Closure:
+		return fn.Call(args)
+	case BuiltinFunction:
+		value, err := fn(args)
+
+		if err != nil {
+			return nil, RuntimeError{Pos: c.pos, Message: err.Error()}
+		}
+
+		return value, nil
+	default:
+		return nil, RuntimeError{Pos: c.pos, Message: fmt.Sprintf("%s is not callable", c.callee.String())}
+	}
+}
+
+// Ternary is the conditional expression `cond ? then : else`, evaluating
+// to then when cond is truthy and to else otherwise.
+type Ternary struct {
+	cond Node
+	then Node
+	els  Node
+	pos  lexer.Position
+}
+
+func (t Ternary) String() string {
+	return fmt.Sprintf("(%s ? %s : %s)", t.cond.String(), t.then.String(), t.els.String())
+}
+
+// Pos returns the position of the ternary's '?' token in the source.
+func (t Ternary) Pos() lexer.Position {
+	return t.pos
+}
+
+func (t Ternary) Eval(env *Environment) (any, error) {
+	cond, err := t.cond.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	truthy, ok := cond.(bool)
+
+	if !ok {
+		return nil, RuntimeError{Pos: t.pos, Message: fmt.Sprintf("ternary condition must be a boolean, got %T", cond)}
+	}
+
+	if truthy {
+		return t.then.Eval(env)
+	}
+
+	return t.els.Eval(env)
+}
+
+// Cond returns the ternary's condition.
+func (t Ternary) Cond() Node {
+	return t.cond
+}
+
+// Then returns the branch evaluated when Cond is truthy.
+func (t Ternary) Then() Node {
+	return t.then
+}
+
+// Else returns the branch evaluated when Cond is falsy.
+func (t Ternary) Else() Node {
+	return t.els
+}
+
 type Unary struct {
 	op   lexer.Token
 	node Node
@@ -49,8 +193,13 @@ func (u Unary) String() string {
 	return fmt.Sprintf("(%s%s)", u.op.Lexeme(), u.node.String())
 }
 
-func (u Unary) Eval() (any, error) {
-	nodeValue, err := u.node.Eval()
+// Pos returns the position of the unary operator in the source.
+func (u Unary) Pos() lexer.Position {
+	return u.op.Pos()
+}
+
+func (u Unary) Eval(env *Environment) (any, error) {
+	nodeValue, err := u.node.Eval(env)
 	op := u.op.Kind()
 
 	if err != nil {
@@ -58,22 +207,22 @@ func (u Unary) Eval() (any, error) {
 	}
 
 	switch value := nodeValue.(type) {
-	case int, float64:
+	case int64, float64:
 		return u.evalNumberOperation(value, op)
 	case bool:
 		return u.evalBooleanOperation(value, op)
 	default:
-		return nil, errors.New("cannot evaluate unary expression")
+		return nil, RuntimeError{Pos: u.Pos(), Message: "cannot evaluate unary expression"}
 	}
 }
 
 func (u Unary) evalNumberOperation(value any, op lexer.TokenKind) (any, error) {
 	if op != lexer.Minus {
-		return nil, errors.New("cannot evaluate numeric sign invertion on unary expression")
+		return nil, RuntimeError{Pos: u.Pos(), Message: "cannot evaluate numeric sign invertion on unary expression"}
 	}
 
 	switch number := value.(type) {
-	case int:
+	case int64:
 		return -number, nil
 	case float64:
 		return -number, nil
@@ -84,26 +233,74 @@ func (u Unary) evalNumberOperation(value any, op lexer.TokenKind) (any, error) {
 
 func (u Unary) evalBooleanOperation(value bool, op lexer.TokenKind) (bool, error) {
 	if op != lexer.Bang {
-		return false, errors.New("cannot evaluate boolean negation on unary expression")
+		return false, RuntimeError{Pos: u.Pos(), Message: "cannot evaluate boolean negation on unary expression"}
 	}
 
 	return !value, nil
 }
 
-func operatorsAsNumbers(left, right any) (float64, float64, error) {
-	leftNumber, ok := left.(float64)
+// Op returns the unary operator token.
+func (u Unary) Op() lexer.Token {
+	return u.op
+}
 
-	if !ok {
-		return 0, 0, errors.New("left operator is not a number")
+// Operand returns the Node the unary operator applies to.
+func (u Unary) Operand() Node {
+	return u.node
+}
+
+// numericOperands normalizes left and right into numbers usable for
+// arithmetic and comparison. Whenever either operand is a float64, the
+// other is promoted from int64 so the operation runs entirely in
+// float64; leftFloat and rightFloat are always populated, even when
+// bothInt is true, since comparisons don't need integer precision.
+func numericOperands(left, right any) (leftInt, rightInt int64, leftFloat, rightFloat float64, bothInt bool, err error) {
+	li, liIsInt := left.(int64)
+	lf, liIsFloat := left.(float64)
+
+	if !liIsInt && !liIsFloat {
+		return 0, 0, 0, 0, false, errors.New("left operand is not a number")
 	}
 
-	rightNumber, ok := right.(float64)
+	ri, riIsInt := right.(int64)
+	rf, riIsFloat := right.(float64)
 
-	if !ok {
-		return 0, 0, errors.New("right operator is not a number")
+	if !riIsInt && !riIsFloat {
+		return 0, 0, 0, 0, false, errors.New("right operand is not a number")
+	}
+
+	if liIsInt && riIsInt {
+		return li, ri, float64(li), float64(ri), true, nil
+	}
+
+	if liIsInt {
+		lf = float64(li)
+	}
+
+	if riIsInt {
+		rf = float64(ri)
 	}
 
-	return leftNumber, rightNumber, nil
+	return 0, 0, lf, rf, false, nil
+}
+
+var intArithmeticOperations = map[lexer.TokenKind]func(left, right int64) (int64, error){
+	lexer.Plus: func(left, right int64) (int64, error) {
+		return left + right, nil
+	},
+	lexer.Minus: func(left, right int64) (int64, error) {
+		return left - right, nil
+	},
+	lexer.Star: func(left, right int64) (int64, error) {
+		return left * right, nil
+	},
+	lexer.Slash: func(left, right int64) (int64, error) {
+		if right == 0 {
+			return 0, errors.New("integer division by zero")
+		}
+
+		return left / right, nil
+	},
 }
 
 var arithmeticOperations = map[lexer.TokenKind]func(left, right float64) float64{
@@ -151,18 +348,38 @@ type Binary struct {
 	right Node
 }
 
+// Left returns the left-hand operand of the binary expression.
+func (b Binary) Left() Node {
+	return b.left
+}
+
+// Op returns the binary operator token.
+func (b Binary) Op() lexer.Token {
+	return b.op
+}
+
+// Right returns the right-hand operand of the binary expression.
+func (b Binary) Right() Node {
+	return b.right
+}
+
 func (b Binary) String() string {
 	return fmt.Sprintf("(%s %s %s)", b.left.String(), b.op.Lexeme(), b.right.String())
 }
 
-func (b Binary) Eval() (any, error) {
-	left, err := b.left.Eval()
+// Pos returns the position of the binary operator in the source.
+func (b Binary) Pos() lexer.Position {
+	return b.op.Pos()
+}
+
+func (b Binary) Eval(env *Environment) (any, error) {
+	left, err := b.left.Eval(env)
 
 	if err != nil {
 		return nil, err
 	}
 
-	right, err := b.right.Eval()
+	right, err := b.right.Eval(env)
 
 	if err != nil {
 		return nil, err
@@ -172,25 +389,47 @@ func (b Binary) Eval() (any, error) {
 
 	switch op {
 	case lexer.Plus, lexer.Minus, lexer.Star, lexer.Slash:
-		leftNumber, rightNumber, err := operatorsAsNumbers(left, right)
+		if op == lexer.Plus {
+			if leftStr, ok := left.(string); ok {
+				rightStr, ok := right.(string)
+
+				if !ok {
+					return nil, RuntimeError{Pos: b.Pos(), Message: "cannot concatenate a string with a non-string"}
+				}
+
+				return leftStr + rightStr, nil
+			}
+		}
+
+		leftInt, rightInt, leftFloat, rightFloat, bothInt, err := numericOperands(left, right)
 
 		if err != nil {
-			return nil, err
+			return nil, RuntimeError{Pos: b.Pos(), Message: err.Error()}
+		}
+
+		if bothInt {
+			result, err := intArithmeticOperations[op](leftInt, rightInt)
+
+			if err != nil {
+				return nil, RuntimeError{Pos: b.Pos(), Message: err.Error()}
+			}
+
+			return result, nil
 		}
 
 		arithmeticFunc := arithmeticOperations[op]
 
-		return arithmeticFunc(leftNumber, rightNumber), nil
+		return arithmeticFunc(leftFloat, rightFloat), nil
 	case lexer.Greater, lexer.GreaterEq, lexer.Less, lexer.LessEq:
-		leftNumber, rightNumber, err := operatorsAsNumbers(left, right)
+		_, _, leftFloat, rightFloat, _, err := numericOperands(left, right)
 
 		if err != nil {
-			return nil, err
+			return nil, RuntimeError{Pos: b.Pos(), Message: err.Error()}
 		}
 
 		comparisonFunc := comparisonOperations[op]
 
-		return comparisonFunc(leftNumber, rightNumber), nil
+		return comparisonFunc(leftFloat, rightFloat), nil
 	case lexer.DoubleEq, lexer.BangEq:
 		equalityFunc := equalityOperations[op]
 
@@ -23,7 +23,7 @@ func TestEvalBinary(t *testing.T) {
 	expectedValues := []any{30.0, 10.0, 25.0, 6.0, true, true, false, true, true, true}
 
 	for i, expr := range exprs {
-		value, _ := expr.Eval()
+		value, _ := expr.Eval(NewEnvironment())
 		expectedValue := expectedValues[i]
 
 		if value != expectedValue {
@@ -35,7 +35,7 @@ func TestEvalBinary(t *testing.T) {
 
 func TestEvalUnary(t *testing.T) {
 	exprs := []Unary{
-		{lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1), Primary{10}},
+		{lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1), Primary{int64(10)}},
 		{lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1), Primary{0.15}},
 		{lexer.NewToken(lexer.Bang, lexer.Bang.Lexeme(), 1), Primary{false}},
 		{lexer.NewToken(lexer.Bang, lexer.Bang.Lexeme(), 1), Unary{
@@ -44,10 +44,10 @@ func TestEvalUnary(t *testing.T) {
 		}},
 	}
 
-	expectedValues := []any{-10, -0.15, true, false}
+	expectedValues := []any{int64(-10), -0.15, true, false}
 
 	for i, expr := range exprs {
-		value, _ := expr.Eval()
+		value, _ := expr.Eval(NewEnvironment())
 		expectedValue := expectedValues[i]
 
 		if value != expectedValue {
@@ -56,6 +56,100 @@ func TestEvalUnary(t *testing.T) {
 	}
 }
 
+func TestEvalBinaryStringConcat(t *testing.T) {
+	expr := Binary{Primary{"Hello, "}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Primary{"world"}}
+
+	value, err := expr.Eval(NewEnvironment())
+
+	if err != nil {
+		t.Fatalf("unexpected error concatenating strings: %s", err)
+	}
+
+	if value != "Hello, world" {
+		t.Errorf("expected concatenated strings, got %#v", value)
+	}
+
+	mismatched := Binary{Primary{"Hello"}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Primary{int64(1)}}
+
+	if _, err := mismatched.Eval(NewEnvironment()); err == nil {
+		t.Error("expected an error concatenating a string with a non-string")
+	}
+}
+
+func TestEvalCallExpression(t *testing.T) {
+	env := NewGlobalEnvironment()
+
+	builtinCall := CallExpression{callee: Identifier{name: "len"}, args: []Node{Primary{"fred"}}}
+	value, err := builtinCall.Eval(env)
+
+	if err != nil {
+		t.Fatalf("unexpected error calling builtin: %s", err)
+	}
+
+	if value != int64(4) {
+		t.Errorf("expected len(\"fred\") to return 4, got %#v", value)
+	}
+
+	double := FunctionDecl{
+		Name:   "double",
+		Params: []string{"n"},
+		Body: Block{Statements: []Node{
+			ReturnStmt{Value: Binary{Identifier{name: "n"}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Identifier{name: "n"}}},
+		}},
+	}
+
+	if _, err := double.Eval(env); err != nil {
+		t.Fatalf("unexpected error declaring function: %s", err)
+	}
+
+	userCall := CallExpression{callee: Identifier{name: "double"}, args: []Node{Primary{int64(21)}}}
+	value, err = userCall.Eval(env)
+
+	if err != nil {
+		t.Fatalf("unexpected error calling user function: %s", err)
+	}
+
+	if value != int64(42) {
+		t.Errorf("expected double(21) to return 42, got %#v", value)
+	}
+
+	notCallable := CallExpression{callee: Primary{int64(1)}}
+
+	if _, err := notCallable.Eval(env); err == nil {
+		t.Error("expected an error calling a non-callable value")
+	}
+}
+
+func TestEvalTernary(t *testing.T) {
+	ternary := Ternary{cond: Primary{true}, then: Primary{"then"}, els: Primary{"else"}}
+
+	value, err := ternary.Eval(NewEnvironment())
+
+	if err != nil || value != "then" {
+		t.Errorf("expected truthy condition to evaluate the then branch, got %#v (err: %v)", value, err)
+	}
+
+	ternary.cond = Primary{false}
+	value, err = ternary.Eval(NewEnvironment())
+
+	if err != nil || value != "else" {
+		t.Errorf("expected falsy condition to evaluate the else branch, got %#v (err: %v)", value, err)
+	}
+
+	pos := lexer.Position{Line: 4, Column: 2}
+	_, err = (Ternary{cond: Primary{"not a bool"}, then: Primary{1}, els: Primary{2}, pos: pos}).Eval(NewEnvironment())
+
+	runtimeErr, ok := err.(RuntimeError)
+
+	if !ok {
+		t.Fatalf("expected a RuntimeError evaluating a ternary with a non-boolean condition, got %#v", err)
+	}
+
+	if runtimeErr.Pos != pos {
+		t.Errorf("expected RuntimeError.Pos %#v, got %#v", pos, runtimeErr.Pos)
+	}
+}
+
 func TestEvalLiteralPrimary(t *testing.T) {
 	exprs := []Primary{
 		{"Hello world"},
@@ -68,7 +162,7 @@ func TestEvalLiteralPrimary(t *testing.T) {
 	expectedValues := []any{"Hello world", 10, 99.9, true, false, nil}
 
 	for i, expr := range exprs {
-		value, _ := expr.Eval()
+		value, _ := expr.Eval(NewEnvironment())
 		expectedValue := expectedValues[i]
 
 		if value != expectedValue {
@@ -1,120 +1,643 @@
 package ast
 
 import (
-	"errors"
 	"fmt"
 	"strconv"
 
 	"github.com/alfredoprograma/fredlang/lexer"
 )
 
+// Precedence levels for parseExpression, lowest to highest binding power.
+const (
+	_ = iota
+	LOWEST
+	TERNARY     // cond ? then : else
+	EQUALS      // == !=
+	LESSGREATER // > >= < <=
+	SUM         // + -
+	PRODUCT     // * /
+	PREFIX      // -x !x
+	CALL        // fn(x)
+	INDEX       // arr[x]
+)
+
+// precedences maps a binary/postfix operator token to the precedence its
+// infix parse function binds at. Tokens absent from this map (statement
+// terminators, closing delimiters, EOF) default to LOWEST, which stops
+// parseExpression's loop.
+var precedences = map[lexer.TokenKind]int{
+	lexer.Question:  TERNARY,
+	lexer.DoubleEq:  EQUALS,
+	lexer.BangEq:    EQUALS,
+	lexer.Greater:   LESSGREATER,
+	lexer.GreaterEq: LESSGREATER,
+	lexer.Less:      LESSGREATER,
+	lexer.LessEq:    LESSGREATER,
+	lexer.Plus:      SUM,
+	lexer.Minus:     SUM,
+	lexer.Star:      PRODUCT,
+	lexer.Slash:     PRODUCT,
+	lexer.LParen:    CALL,
+}
+
+// Parser is a Pratt parser: expressions are parsed by looking up a
+// prefixParseFn for the current token and then, as long as the next
+// token's precedence outranks the precedence parseExpression was called
+// with, feeding the result through the matching infixParseFn. Grammar
+// levels are added by registering functions through RegisterPrefix and
+// RegisterInfix rather than by writing a new recursive-descent method.
 type Parser struct {
 	tokens  []lexer.Token
 	errors  []error
 	current int
+
+	prefixParseFns map[lexer.TokenKind]func() Node
+	infixParseFns  map[lexer.TokenKind]func(Node) Node
 }
 
-func NewParser(tokens []lexer.Token) Parser {
-	return Parser{
-		errors:  []error{},
-		tokens:  tokens,
-		current: 0,
+func NewParser(tokens []lexer.Token) *Parser {
+	p := &Parser{
+		errors:         []error{},
+		tokens:         tokens,
+		current:        0,
+		prefixParseFns: map[lexer.TokenKind]func() Node{},
+		infixParseFns:  map[lexer.TokenKind]func(Node) Node{},
 	}
+
+	p.RegisterPrefix(lexer.Minus, p.parsePrefixExpression)
+	p.RegisterPrefix(lexer.Bang, p.parsePrefixExpression)
+	p.RegisterPrefix(lexer.LParen, p.parseGroupExpression)
+	p.RegisterPrefix(lexer.Identifier, p.parseIdentifierExpression)
+	p.RegisterPrefix(lexer.String, p.parseLiteral)
+	p.RegisterPrefix(lexer.Integer, p.parseLiteral)
+	p.RegisterPrefix(lexer.Float, p.parseLiteral)
+	p.RegisterPrefix(lexer.True, p.parseLiteral)
+	p.RegisterPrefix(lexer.False, p.parseLiteral)
+	p.RegisterPrefix(lexer.Null, p.parseLiteral)
+
+	p.RegisterInfix(lexer.Plus, p.parseInfixExpression)
+	p.RegisterInfix(lexer.Minus, p.parseInfixExpression)
+	p.RegisterInfix(lexer.Star, p.parseInfixExpression)
+	p.RegisterInfix(lexer.Slash, p.parseInfixExpression)
+	p.RegisterInfix(lexer.Greater, p.parseInfixExpression)
+	p.RegisterInfix(lexer.GreaterEq, p.parseInfixExpression)
+	p.RegisterInfix(lexer.Less, p.parseInfixExpression)
+	p.RegisterInfix(lexer.LessEq, p.parseInfixExpression)
+	p.RegisterInfix(lexer.DoubleEq, p.parseInfixExpression)
+	p.RegisterInfix(lexer.BangEq, p.parseInfixExpression)
+	p.RegisterInfix(lexer.LParen, p.parseCallExpression)
+	p.RegisterInfix(lexer.Question, p.parseTernaryExpression)
+
+	return p
+}
+
+// RegisterPrefix binds fn as the prefix parse function for kind, letting
+// downstream code extend the expression grammar (e.g. a new literal kind
+// or prefix operator) without editing the parser itself.
+func (p *Parser) RegisterPrefix(kind lexer.TokenKind, fn func() Node) {
+	p.prefixParseFns[kind] = fn
 }
 
-func (p *Parser) Parse() (Node, []error) {
-	return p.parseEquality(), p.errors
+// RegisterInfix binds fn as the infix parse function for kind.
+func (p *Parser) RegisterInfix(kind lexer.TokenKind, fn func(Node) Node) {
+	p.infixParseFns[kind] = fn
 }
 
-func (p *Parser) parseEquality() Node {
-	left := p.parseComparison()
+// Parse consumes the token stream up to EOF, or up to the first Err
+// token, whose error is appended to the parse errors, and returns the
+// resulting Program together with any errors gathered along the way.
+func (p *Parser) Parse() (Program, []error) {
+	statements := []Node{}
+
+	for !p.atEOF() {
+		if p.match(lexer.RBrace) {
+			p.registerError("Unexpected '}'")
+			p.advance()
+			continue
+		}
+
+		stmt := p.parseStatement()
 
-	for !p.isEnd() && p.match(lexer.DoubleEq, lexer.BangEq) {
-		op := p.peek()
-		p.advance()
-		right := p.parseComparison()
-		left = Binary{left, op, right}
+		if stmt != nil {
+			statements = append(statements, stmt)
+		}
 	}
 
-	return left
+	if !p.isEnd() && p.peek().Kind() == lexer.Err {
+		p.errors = append(p.errors, p.peek().Err())
+	}
+
+	return Program{Statements: statements}, p.errors
 }
 
-func (p *Parser) parseComparison() Node {
-	left := p.parseTerm()
+// atEOF reports whether the parser has reached the end of the token
+// stream. An Err token stops parsing in place, the same as EOF, rather
+// than being advanced past as an ordinary token.
+func (p *Parser) atEOF() bool {
+	if p.isEnd() {
+		return true
+	}
+
+	kind := p.peek().Kind()
+	return kind == lexer.EOF || kind == lexer.Err
+}
 
-	for !p.isEnd() && p.match(lexer.Greater, lexer.GreaterEq, lexer.Less, lexer.LessEq) {
-		op := p.peek()
+func (p *Parser) parseStatement() Node {
+	switch p.peek().Kind() {
+	case lexer.Var:
+		return p.parseVarDecl()
+	case lexer.LBrace:
+		return p.parseBlockStatement()
+	case lexer.If:
+		return p.parseIfStatement()
+	case lexer.While:
+		return p.parseWhileStatement()
+	case lexer.For:
+		return p.parseForStatement()
+	case lexer.Function:
+		return p.parseFunctionDecl()
+	case lexer.Return:
+		return p.parseReturnStatement()
+	case lexer.Break:
+		return p.parseBreakStatement()
+	case lexer.Print:
+		return p.parsePrintStatement()
+	case lexer.Identifier:
+		if p.peekNext().Kind() == lexer.Eq {
+			return p.parseAssignStatement()
+		}
+
+		return p.parseExprStatement()
+	default:
+		return p.parseExprStatement()
+	}
+}
+
+func (p *Parser) parseVarDecl() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'var'
+	name := p.peek()
+
+	if !p.match(lexer.Identifier) {
+		p.registerError("Expected identifier after 'var'")
+		return nil
+	}
+	p.advance()
+
+	if !p.match(lexer.Eq) {
+		p.registerError("Expected '=' in variable declaration")
+		return nil
+	}
+	p.advance()
+
+	value := p.parseExpression(LOWEST)
+	p.consumeSemicolon()
+
+	return VarDecl{Name: name.Lexeme(), Value: value, Pos: pos}
+}
+
+func (p *Parser) parseAssignStatement() Node {
+	pos := p.peek().Pos()
+	name := p.peek()
+	p.advance() // identifier
+	p.advance() // '='
+
+	value := p.parseExpression(LOWEST)
+	p.consumeSemicolon()
+
+	return Assign{Name: name.Lexeme(), Value: value, Pos: pos}
+}
+
+func (p *Parser) parseExprStatement() Node {
+	pos := p.peek().Pos()
+	expr := p.parseExpression(LOWEST)
+	p.consumeSemicolon()
+
+	return ExprStmt{Expr: expr, Pos: pos}
+}
+
+func (p *Parser) parsePrintStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'print'
+
+	expr := p.parseExpression(LOWEST)
+	p.consumeSemicolon()
+
+	return PrintStmt{Expr: expr, Pos: pos}
+}
+
+func (p *Parser) parseBlockStatement() Block {
+	pos := p.peek().Pos()
+	p.advance() // '{'
+	statements := []Node{}
+
+	for !p.atEOF() && !p.match(lexer.RBrace) {
+		stmt := p.parseStatement()
+
+		if stmt != nil {
+			statements = append(statements, stmt)
+		}
+	}
+
+	if !p.match(lexer.RBrace) {
+		p.registerError("Expected '}' to close block")
+	} else {
 		p.advance()
-		right := p.parseTerm()
-		left = Binary{left, op, right}
 	}
 
-	return left
+	return Block{Statements: statements, Pos: pos}
 }
 
-func (p *Parser) parseTerm() Node {
-	left := p.parseFactor()
+func (p *Parser) parseIfStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'if'
+
+	if !p.match(lexer.LParen) {
+		p.registerError("Expected '(' after 'if'")
+		return nil
+	}
+	p.advance()
+
+	cond := p.parseExpression(LOWEST)
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Expected ')' after if condition")
+		return nil
+	}
+	p.advance()
+
+	if !p.match(lexer.LBrace) {
+		p.registerError("Expected '{' to start if block")
+		return nil
+	}
+	then := p.parseBlockStatement()
+
+	elseIfs := []ElseIfBranch{}
+
+	for p.match(lexer.Elseif) {
+		p.advance()
 
-	for !p.isEnd() && p.match(lexer.Plus, lexer.Minus) {
-		op := p.peek()
+		if !p.match(lexer.LParen) {
+			p.registerError("Expected '(' after 'elseif'")
+			break
+		}
 		p.advance()
-		right := p.parseFactor()
-		left = Binary{left, op, right}
+
+		elseIfCond := p.parseExpression(LOWEST)
+
+		if !p.match(lexer.RParen) {
+			p.registerError("Expected ')' after elseif condition")
+			break
+		}
+		p.advance()
+
+		if !p.match(lexer.LBrace) {
+			p.registerError("Expected '{' to start elseif block")
+			break
+		}
+
+		elseIfs = append(elseIfs, ElseIfBranch{Cond: elseIfCond, Body: p.parseBlockStatement()})
 	}
 
-	return left
+	var elseBranch Node
+
+	if p.match(lexer.Else) {
+		p.advance()
+
+		switch {
+		case p.match(lexer.If):
+			elseBranch = p.parseIfStatement()
+		case p.match(lexer.LBrace):
+			elseBranch = p.parseBlockStatement()
+		default:
+			p.registerError("Expected '{' or 'if' after 'else'")
+		}
+	}
+
+	return IfStmt{Cond: cond, Then: then, ElseIfs: elseIfs, Else: elseBranch, Pos: pos}
 }
 
-func (p *Parser) parseFactor() Node {
-	left := p.parseUnary()
+func (p *Parser) parseWhileStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'while'
 
-	for !p.isEnd() && p.match(lexer.Star, lexer.Slash) {
-		op := p.peek()
+	if !p.match(lexer.LParen) {
+		p.registerError("Expected '(' after 'while'")
+		return nil
+	}
+	p.advance()
+
+	cond := p.parseExpression(LOWEST)
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Expected ')' after while condition")
+		return nil
+	}
+	p.advance()
+
+	if !p.match(lexer.LBrace) {
+		p.registerError("Expected '{' to start while block")
+		return nil
+	}
+	body := p.parseBlockStatement()
+
+	return WhileStmt{Cond: cond, Body: body, Pos: pos}
+}
+
+func (p *Parser) parseForStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'for'
+
+	if !p.match(lexer.LParen) {
+		p.registerError("Expected '(' after 'for'")
+		return nil
+	}
+	p.advance()
+
+	var init Node
+
+	if p.match(lexer.Var) {
+		varPos := p.peek().Pos()
 		p.advance()
-		right := p.parseUnary()
-		left = Binary{left, op, right}
+		name := p.peek()
+
+		if !p.match(lexer.Identifier) {
+			p.registerError("Expected identifier after 'var'")
+		} else {
+			p.advance()
+		}
+
+		if !p.match(lexer.Eq) {
+			p.registerError("Expected '=' in for-loop initializer")
+		} else {
+			p.advance()
+		}
+
+		init = VarDecl{Name: name.Lexeme(), Value: p.parseExpression(LOWEST), Pos: varPos}
+	} else if !p.match(lexer.Semicolon) {
+		init = p.parseExpression(LOWEST)
 	}
 
-	return left
+	if !p.match(lexer.Semicolon) {
+		p.registerError("Expected ';' after for-loop initializer")
+	} else {
+		p.advance()
+	}
+
+	var cond Node
+
+	if !p.match(lexer.Semicolon) {
+		cond = p.parseExpression(LOWEST)
+	}
+
+	if !p.match(lexer.Semicolon) {
+		p.registerError("Expected ';' after for-loop condition")
+	} else {
+		p.advance()
+	}
+
+	var post Node
+
+	if !p.match(lexer.RParen) {
+		if p.match(lexer.Identifier) && p.peekNext().Kind() == lexer.Eq {
+			name := p.peek()
+			assignPos := name.Pos()
+			p.advance()
+			p.advance()
+			post = Assign{Name: name.Lexeme(), Value: p.parseExpression(LOWEST), Pos: assignPos}
+		} else {
+			post = p.parseExpression(LOWEST)
+		}
+	}
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Expected ')' after for-loop clauses")
+	} else {
+		p.advance()
+	}
+
+	if !p.match(lexer.LBrace) {
+		p.registerError("Expected '{' to start for-loop block")
+		return nil
+	}
+	body := p.parseBlockStatement()
+
+	return ForStmt{Init: init, Cond: cond, Post: post, Body: body, Pos: pos}
 }
 
-func (p *Parser) parseUnary() Node {
-	if p.match(lexer.Minus, lexer.Bang) {
-		op := p.peek()
+func (p *Parser) parseFunctionDecl() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'function'
+	name := p.peek()
+
+	if !p.match(lexer.Identifier) {
+		p.registerError("Expected function name")
+		return nil
+	}
+	p.advance()
+
+	if !p.match(lexer.LParen) {
+		p.registerError("Expected '(' after function name")
+		return nil
+	}
+	p.advance()
+
+	params := []string{}
+
+	if !p.match(lexer.RParen) {
+		for {
+			param := p.peek()
+
+			if !p.match(lexer.Identifier) {
+				p.registerError("Expected parameter name")
+				break
+			}
+			p.advance()
+			params = append(params, param.Lexeme())
+
+			if !p.match(lexer.Comma) {
+				break
+			}
+			p.advance()
+		}
+	}
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Expected ')' after parameters")
+	} else {
 		p.advance()
-		node := p.parseUnary()
+	}
 
-		return Unary{op, node}
+	if !p.match(lexer.LBrace) {
+		p.registerError("Expected '{' to start function body")
+		return nil
 	}
+	body := p.parseBlockStatement()
 
-	return p.parsePrimary()
+	return FunctionDecl{Name: name.Lexeme(), Params: params, Body: body, Pos: pos}
 }
 
-func (p *Parser) parsePrimary() Node {
-	if p.match(lexer.LParen) {
+func (p *Parser) parseReturnStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'return'
+
+	if p.match(lexer.Semicolon) {
 		p.advance()
-		value, _ := p.Parse() // Top level parse expression
+		return ReturnStmt{Pos: pos}
+	}
 
-		if !p.match(lexer.RParen) {
-			p.registerError("Unterminated group expression")
-		}
+	value := p.parseExpression(LOWEST)
+	p.consumeSemicolon()
 
+	return ReturnStmt{Value: value, Pos: pos}
+}
+
+func (p *Parser) parseBreakStatement() Node {
+	pos := p.peek().Pos()
+	p.advance() // 'break'
+	p.consumeSemicolon()
+
+	return Break{Pos: pos}
+}
+
+func (p *Parser) consumeSemicolon() {
+	if p.match(lexer.Semicolon) {
 		p.advance()
+		return
+	}
+
+	p.registerError("Expected ';' after statement")
+}
+
+// parseExpression is the entry point of the Pratt parser: it looks up the
+// prefixParseFn for the current token to produce a left-hand expression,
+// then repeatedly consumes infix operators whose precedence outranks
+// precedence, feeding the growing expression through their infixParseFn.
+func (p *Parser) parseExpression(precedence int) Node {
+	prefix := p.prefixParseFns[p.peek().Kind()]
+
+	if prefix == nil {
+		p.registerError("Literal expected")
+		p.synchronize()
+		return nil
+	}
+
+	left := prefix()
+
+	for !p.atEOF() && precedence < p.peekPrecedence() {
+		infix := p.infixParseFns[p.peek().Kind()]
+
+		if infix == nil {
+			return left
+		}
+
+		left = infix(left)
+	}
+
+	return left
+}
+
+// peekPrecedence returns the binding power of the current token, or
+// LOWEST if it isn't a registered infix operator.
+func (p *Parser) peekPrecedence() int {
+	if prec, ok := precedences[p.peek().Kind()]; ok {
+		return prec
+	}
+
+	return LOWEST
+}
+
+// parsePrefixExpression handles unary `-x` and `!x`.
+func (p *Parser) parsePrefixExpression() Node {
+	op := p.peek()
+	p.advance()
+	operand := p.parseExpression(PREFIX)
+
+	return Unary{op, operand}
+}
+
+// parseInfixExpression handles the arithmetic, comparison and equality
+// binary operators, left-associatively.
+func (p *Parser) parseInfixExpression(left Node) Node {
+	op := p.peek()
+	precedence := precedences[op.Kind()]
+	p.advance()
+	right := p.parseExpression(precedence)
+
+	return Binary{left, op, right}
+}
+
+// parseTernaryExpression handles the `? then : else` tail of a ternary
+// conditional, with cond already parsed as left. The else branch is
+// parsed at LOWEST so a chain of ternaries nests to the right:
+// `a ? b : c ? d : e` parses as `a ? b : (c ? d : e)`.
+func (p *Parser) parseTernaryExpression(cond Node) Node {
+	pos := p.peek().Pos()
+	p.advance() // '?'
+	then := p.parseExpression(LOWEST)
+
+	if !p.match(lexer.Colon) {
+		p.registerError("Expected ':' in ternary expression")
+		return Ternary{cond, then, nil, pos}
+	}
+	p.advance()
+
+	els := p.parseExpression(LOWEST)
+
+	return Ternary{cond, then, els, pos}
+}
+
+// parseGroupExpression handles a parenthesized expression used for
+// grouping, as opposed to the call-expression use of '(' registered as
+// an infix parse function.
+func (p *Parser) parseGroupExpression() Node {
+	p.advance() // '('
+	value := p.parseExpression(LOWEST)
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Unterminated group expression")
+		p.synchronize()
 		return Primary{value}
 	}
 
-	if p.match(
-		lexer.String,
-		lexer.Number,
-		lexer.True,
-		lexer.False,
-		lexer.Null,
-	) {
-		return p.parseLiteral()
+	p.advance()
+	return Primary{value}
+}
+
+// parseIdentifierExpression handles a bare variable or function reference.
+func (p *Parser) parseIdentifierExpression() Node {
+	token := p.peek()
+	p.advance()
+
+	return Identifier{name: token.Lexeme(), pos: token.Pos()}
+}
+
+// parseCallExpression handles the `(args...)` tail of a call expression,
+// with the callee already parsed as left.
+func (p *Parser) parseCallExpression(callee Node) Node {
+	pos := p.peek().Pos()
+	p.advance() // '('
+
+	args := []Node{}
+
+	if !p.match(lexer.RParen) {
+		for {
+			args = append(args, p.parseExpression(LOWEST))
+
+			if !p.match(lexer.Comma) {
+				break
+			}
+			p.advance()
+		}
+	}
+
+	if !p.match(lexer.RParen) {
+		p.registerError("Expected ')' after call arguments")
+	} else {
+		p.advance()
 	}
 
-	p.registerError("Literal expected")
-	return nil
+	return CallExpression{callee: callee, args: args, pos: pos}
 }
 
 func (p *Parser) parseLiteral() Node {
@@ -126,8 +649,16 @@ func (p *Parser) parseLiteral() Node {
 	switch kind {
 	case lexer.String:
 		value = lexeme
-	case lexer.Number:
-		number, err := strconv.ParseFloat(lexeme, 32)
+	case lexer.Integer:
+		number, err := strconv.ParseInt(lexeme, 10, 64)
+
+		if err != nil {
+			panic(fmt.Sprintf("cannot parse lexeme from given token as integer: %#v", token))
+		}
+
+		value = number
+	case lexer.Float:
+		number, err := strconv.ParseFloat(lexeme, 64)
 
 		if err != nil {
 			panic(fmt.Sprintf("cannot parse lexeme from given token as float: %#v", token))
@@ -175,10 +706,46 @@ func (p *Parser) peek() lexer.Token {
 	return p.tokens[p.current]
 }
 
+// peekNext looks one token past the current one without consuming
+// anything, returning the trailing EOF token once the stream is
+// exhausted.
+func (p *Parser) peekNext() lexer.Token {
+	if p.current+1 >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+
+	return p.tokens[p.current+1]
+}
+
 func (p *Parser) isEnd() bool {
 	return p.current >= len(p.tokens)
 }
 
 func (p *Parser) registerError(message string) {
-	p.errors = append(p.errors, errors.New(message))
+	token := p.peek()
+	lexeme := token.Lexeme()
+
+	if lexeme == "" {
+		lexeme = string(token.Kind())
+	}
+
+	p.errors = append(p.errors, SyntaxError{Pos: token.Pos(), Message: fmt.Sprintf("%s near '%s'", message, lexeme)})
+}
+
+// synchronize recovers from a parse error by discarding tokens until it
+// reaches a statement boundary, so one malformed construct doesn't
+// cascade into spurious errors for everything that follows it. The
+// boundary token itself (';', '}', or EOF) is left in place rather than
+// consumed, so the statement-level code that called us (consumeSemicolon,
+// parseBlockStatement, Parse) still gets to see and handle it normally.
+func (p *Parser) synchronize() {
+	for !p.atEOF() {
+		kind := p.peek().Kind()
+
+		if kind == lexer.Semicolon || kind == lexer.RBrace {
+			return
+		}
+
+		p.advance()
+	}
 }
@@ -1,6 +1,7 @@
 package ast
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/alfredoprograma/fredlang/lexer"
@@ -22,7 +23,7 @@ func TestParseEquality(t *testing.T) {
 	expectedStringification := "(true == false)"
 
 	p := NewParser(tokens)
-	equality := p.parseEquality()
+	equality := p.parseExpression(LOWEST)
 
 	if equality != expectedEquality {
 		t.Errorf("mismatching equality expression. expected %#v, but got %#v", expectedEquality, equality)
@@ -41,15 +42,15 @@ func TestParseComparison(t *testing.T) {
 	}
 
 	expectedComparison := Binary{
-		left:  Primary{80},
+		left:  Primary{int64(80)},
 		op:    lexer.NewToken(lexer.GreaterEq, lexer.GreaterEq.Lexeme(), 1),
-		right: Primary{35},
+		right: Primary{int64(35)},
 	}
 
 	expectedStringification := "(80 >= 35)"
 
 	p := NewParser(tokens)
-	comparison := p.parseComparison()
+	comparison := p.parseExpression(LOWEST)
 
 	if comparison != expectedComparison {
 		t.Errorf("mismatching comparison expression. expected %#v, but got %#v", expectedComparison, comparison)
@@ -68,15 +69,15 @@ func TestParseTerm(t *testing.T) {
 	}
 
 	expectedTerm := Binary{
-		left:  Primary{20},
+		left:  Primary{int64(20)},
 		op:    lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1),
-		right: Primary{10},
+		right: Primary{int64(10)},
 	}
 
 	expectedStringification := "(20 - 10)"
 
 	p := NewParser(tokens)
-	term := p.parseTerm()
+	term := p.parseExpression(LOWEST)
 
 	if term != expectedTerm {
 		t.Errorf("mismatching term expression. expected %#v, but got %#v", expectedTerm, term)
@@ -95,15 +96,15 @@ func TestParseFactor(t *testing.T) {
 	}
 
 	expectedFactor := Binary{
-		left:  Primary{5},
+		left:  Primary{int64(5)},
 		op:    lexer.NewToken(lexer.Star, lexer.Star.Lexeme(), 1),
-		right: Primary{12},
+		right: Primary{int64(12)},
 	}
 
 	expectedStringification := "(5 * 12)"
 
 	p := NewParser(tokens)
-	factor := p.parseFactor()
+	factor := p.parseExpression(LOWEST)
 
 	if factor != expectedFactor {
 		t.Errorf("mismatching factor expression. expected %#v, but got %#v", expectedFactor, factor)
@@ -122,12 +123,12 @@ func TestParseUnary(t *testing.T) {
 
 	expectedUnary := Unary{
 		op:   lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1),
-		node: Primary{10},
+		node: Primary{int64(10)},
 	}
 	expectedStringification := "(-10)"
 
 	p := NewParser(tokens)
-	unary := p.parseUnary()
+	unary := p.parseExpression(LOWEST)
 
 	if unary != expectedUnary {
 		t.Errorf("mismatching unary expression. expected %#v, but got %#v", expectedUnary, unary)
@@ -150,7 +151,7 @@ func TestParseLiteral(t *testing.T) {
 
 	expectedExprs := []Primary{
 		{"Hello world"},
-		{10},
+		{int64(10)},
 		{15.5},
 		{true},
 		{false},
@@ -180,9 +181,305 @@ func TestParseGroup(t *testing.T) {
 	}
 
 	p := NewParser(tokens)
-	group := p.parsePrimary()
+	group := p.parseExpression(LOWEST)
 
 	if group != expectedExpr {
 		t.Errorf("mismatching group expression. expected %#v, but got %#v", group, expectedExpr)
 	}
 }
+
+func TestParseTernary(t *testing.T) {
+	tokens := []lexer.Token{
+		lexer.NewToken(lexer.True, "true", 1),
+		lexer.NewToken(lexer.Question, lexer.Question.Lexeme(), 1),
+		lexer.NewToken(lexer.Integer, "1", 1),
+		lexer.NewToken(lexer.Colon, lexer.Colon.Lexeme(), 1),
+		lexer.NewToken(lexer.Integer, "2", 1),
+	}
+
+	expectedTernary := Ternary{
+		cond: Primary{true},
+		then: Primary{int64(1)},
+		els:  Primary{int64(2)},
+		pos:  tokens[1].Pos(),
+	}
+
+	expectedStringification := "(true ? 1 : 2)"
+
+	p := NewParser(tokens)
+	ternary := p.parseExpression(LOWEST)
+
+	if ternary != expectedTernary {
+		t.Errorf("mismatching ternary expression. expected %#v, but got %#v", expectedTernary, ternary)
+	}
+
+	if ternary.String() != expectedStringification {
+		t.Errorf("mismatching ternary stringification. expected %s, but got %s", expectedStringification, ternary.String())
+	}
+}
+
+func TestParseTernaryNestsRightAssociatively(t *testing.T) {
+	lx := lexer.New(`a ? b : c ? d : e`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	ternary := p.parseExpression(LOWEST)
+
+	expectedStringification := "(a ? b : (c ? d : e))"
+
+	if ternary.String() != expectedStringification {
+		t.Errorf("mismatching ternary stringification. expected %s, but got %s", expectedStringification, ternary.String())
+	}
+}
+
+func TestParseTernaryBindsLooserThanEquality(t *testing.T) {
+	lx := lexer.New(`a == b ? x : y`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	ternary := p.parseExpression(LOWEST)
+
+	expectedStringification := "((a == b) ? x : y)"
+
+	if ternary.String() != expectedStringification {
+		t.Errorf("mismatching ternary stringification. expected %s, but got %s", expectedStringification, ternary.String())
+	}
+}
+
+func TestParseTernaryWithUnaryAndBinaryOperands(t *testing.T) {
+	lx := lexer.New(`!a ? -1 + 2 : 3 * 4`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	ternary := p.parseExpression(LOWEST)
+
+	expectedStringification := "((!a) ? ((-1) + 2) : (3 * 4))"
+
+	if ternary.String() != expectedStringification {
+		t.Errorf("mismatching ternary stringification. expected %s, but got %s", expectedStringification, ternary.String())
+	}
+}
+
+func TestParseCallExpression(t *testing.T) {
+	lx := lexer.New(`add(1, 2)`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	call := p.parseExpression(LOWEST)
+
+	expectedStringification := "add(1, 2)"
+
+	if call.String() != expectedStringification {
+		t.Errorf("mismatching call stringification. expected %s, but got %s", expectedStringification, call.String())
+	}
+}
+
+func TestParseIfStatementWithElseIfChain(t *testing.T) {
+	lx := lexer.New(`if (a) { print 1; } elseif (b) { print 2; } elseif (c) { print 3; } else { print 4; }`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	program, errs := p.Parse()
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected parse errors: %#v", errs)
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expected a single if statement, got %d", len(program.Statements))
+	}
+
+	ifStmt, ok := program.Statements[0].(IfStmt)
+
+	if !ok {
+		t.Fatalf("expected an IfStmt, got %#v", program.Statements[0])
+	}
+
+	if len(ifStmt.ElseIfs) != 2 {
+		t.Fatalf("expected two elseif branches, got %d", len(ifStmt.ElseIfs))
+	}
+
+	if ifStmt.Else == nil {
+		t.Error("expected a trailing else branch")
+	}
+
+	expectedStringification := "if (a) {\nprint 1;\n} elseif (b) {\nprint 2;\n} elseif (c) {\nprint 3;\n} else {\nprint 4;\n}"
+
+	if ifStmt.String() != expectedStringification {
+		t.Errorf("mismatching if stringification. expected %s, but got %s", expectedStringification, ifStmt.String())
+	}
+}
+
+func TestParseRecoversFromMissingOperand(t *testing.T) {
+	lx := lexer.New(`1 + ;`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	_, errs := p.Parse()
+
+	if !p.atEOF() {
+		t.Error("expected the parser to recover and reach EOF")
+	}
+
+	if len(errs) == 0 {
+		t.Fatal("expected at least one parse error")
+	}
+
+	if got := errs[0].Error(); !strings.Contains(got, "Literal expected") {
+		t.Errorf("expected the first error to report a missing literal, got %q", got)
+	}
+}
+
+func TestParseRecoversFromUnterminatedGroup(t *testing.T) {
+	lx := lexer.New(`(1 + 2`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	_, errs := p.Parse()
+
+	if !p.atEOF() {
+		t.Error("expected the parser to recover and reach EOF instead of crashing")
+	}
+
+	if len(errs) == 0 {
+		t.Fatal("expected at least one parse error")
+	}
+
+	if got := errs[0].Error(); !strings.Contains(got, "Unterminated group expression") {
+		t.Errorf("expected the first error to report an unterminated group, got %q", got)
+	}
+}
+
+func TestParseRecoversFromStrayClosingParen(t *testing.T) {
+	lx := lexer.New(`)`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	_, errs := p.Parse()
+
+	if !p.atEOF() {
+		t.Error("expected the parser to recover and reach EOF instead of looping forever")
+	}
+
+	if len(errs) == 0 {
+		t.Fatal("expected at least one parse error")
+	}
+}
+
+func TestParseRecoversFromStrayClosingBrace(t *testing.T) {
+	lx := lexer.New(`}`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	_, errs := p.Parse()
+
+	if !p.atEOF() {
+		t.Error("expected the parser to recover and reach EOF instead of looping forever")
+	}
+
+	if len(errs) == 0 {
+		t.Fatal("expected at least one parse error")
+	}
+}
+
+func TestParseStopsAtErrToken(t *testing.T) {
+	lx := lexer.New(`print "ok"; "unterminated`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 1 {
+		t.Fatalf("expected the lexer to report a single error, got %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	program, errs := p.Parse()
+
+	if len(program.Statements) != 1 {
+		t.Errorf("expected parsing to stop at the Err token after one statement, got %d", len(program.Statements))
+	}
+
+	if len(errs) != 1 || errs[0] != lexErrors[0] {
+		t.Errorf("expected the parser to surface the lexer's error, got %#v", errs)
+	}
+}
+
+// TestParseForStatementSetsInitAndPostPos checks that the VarDecl/Assign
+// built for a for-loop's init and post clauses carry the position of their
+// own token, like every other statement, instead of the lexer.Position
+// zero value.
+func TestParseForStatementSetsInitAndPostPos(t *testing.T) {
+	lx := lexer.New(`for (var i = 0; i < 1; i = i + 1) { }`)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	p := NewParser(tokens)
+	program, errs := p.Parse()
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected parse errors: %#v", errs)
+	}
+
+	forStmt, ok := program.Statements[0].(ForStmt)
+
+	if !ok {
+		t.Fatalf("expected a ForStmt, got %#v", program.Statements[0])
+	}
+
+	init, ok := forStmt.Init.(VarDecl)
+
+	if !ok {
+		t.Fatalf("expected the initializer to be a VarDecl, got %#v", forStmt.Init)
+	}
+
+	if init.Pos == (lexer.Position{}) {
+		t.Error("expected the for-loop initializer's Pos to be set")
+	}
+
+	post, ok := forStmt.Post.(Assign)
+
+	if !ok {
+		t.Fatalf("expected the post-clause to be an Assign, got %#v", forStmt.Post)
+	}
+
+	if post.Pos == (lexer.Position{}) {
+		t.Error("expected the for-loop post-clause's Pos to be set")
+	}
+}
@@ -0,0 +1,474 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+// breakSignal is returned as the error half of Eval by a Break statement
+// and unwound by the nearest enclosing loop. Pos carries the break's own
+// position, so a misplaced `break;` outside any loop still reports a real
+// file:line:col instead of a bare message.
+type breakSignal struct {
+	Pos lexer.Position
+}
+
+func (b breakSignal) Error() string {
+	return fmt.Sprintf("%s: break outside of a loop", b.Pos)
+}
+
+// returnSignal is returned as the error half of Eval by a ReturnStmt and
+// unwound by the function call that is executing the body. Pos carries the
+// return's own position, so a misplaced `return;` outside any function
+// still reports a real file:line:col instead of a bare message.
+type returnSignal struct {
+	value any
+	Pos   lexer.Position
+}
+
+func (r returnSignal) Error() string {
+	return fmt.Sprintf("%s: return outside of a function", r.Pos)
+}
+
+// Program is the root node produced by parsing a whole source file: a
+// sequence of statements executed in order.
+type Program struct {
+	Statements []Node
+}
+
+func (p Program) String() string {
+	var sb strings.Builder
+
+	for _, stmt := range p.Statements {
+		sb.WriteString(stmt.String())
+		sb.WriteRune('\n')
+	}
+
+	return sb.String()
+}
+
+func (p Program) Eval(env *Environment) (any, error) {
+	var value any
+	var err error
+
+	for _, stmt := range p.Statements {
+		value, err = stmt.Eval(env)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// VarDecl declares a new variable in the current scope: `var name = value;`.
+type VarDecl struct {
+	Name  string
+	Value Node
+	Pos   lexer.Position
+}
+
+func (v VarDecl) String() string {
+	return fmt.Sprintf("var %s = %s;", v.Name, v.Value.String())
+}
+
+func (v VarDecl) Eval(env *Environment) (any, error) {
+	value, err := v.Value.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	env.Define(v.Name, value)
+	return value, nil
+}
+
+// Assign rebinds an already-declared variable: `name = value;`.
+type Assign struct {
+	Name  string
+	Value Node
+	Pos   lexer.Position
+}
+
+func (a Assign) String() string {
+	return fmt.Sprintf("%s = %s;", a.Name, a.Value.String())
+}
+
+func (a Assign) Eval(env *Environment) (any, error) {
+	value, err := a.Value.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := env.Assign(a.Name, value); err != nil {
+		return nil, RuntimeError{Pos: a.Pos, Message: err.Error()}
+	}
+
+	return value, nil
+}
+
+// ExprStmt evaluates an expression purely for its side effects, discarding
+// or surfacing the result depending on the caller (e.g. the REPL).
+type ExprStmt struct {
+	Expr Node
+	Pos  lexer.Position
+}
+
+func (e ExprStmt) String() string {
+	return fmt.Sprintf("%s;", e.Expr.String())
+}
+
+func (e ExprStmt) Eval(env *Environment) (any, error) {
+	return e.Expr.Eval(env)
+}
+
+// PrintStmt prints the value of an expression to stdout: `print value;`.
+type PrintStmt struct {
+	Expr Node
+	Pos  lexer.Position
+}
+
+func (p PrintStmt) String() string {
+	return fmt.Sprintf("print %s;", p.Expr.String())
+}
+
+func (p PrintStmt) Eval(env *Environment) (any, error) {
+	value, err := p.Expr.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println(value)
+	return value, nil
+}
+
+// Block is a `{ ... }` sequence of statements executed in a new scope
+// enclosed by the scope it was declared in.
+type Block struct {
+	Statements []Node
+	Pos        lexer.Position
+}
+
+func (b Block) String() string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+
+	for _, stmt := range b.Statements {
+		sb.WriteString(stmt.String())
+		sb.WriteRune('\n')
+	}
+
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (b Block) Eval(env *Environment) (any, error) {
+	scope := NewEnclosedEnvironment(env)
+	var value any
+	var err error
+
+	for _, stmt := range b.Statements {
+		value, err = stmt.Eval(scope)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// ElseIfBranch is one `elseif (cond) { ... }` clause chained onto an
+// IfStmt, checked in order after Then and before Else.
+type ElseIfBranch struct {
+	Cond Node
+	Body Block
+}
+
+// IfStmt evaluates Then when Cond is truthy, otherwise checks each
+// ElseIfs branch in order, falling back to Else if none matched. Else
+// may also be another IfStmt, which is how `else if` chains are formed
+// alongside the flattened `elseif` branches.
+type IfStmt struct {
+	Cond    Node
+	Then    Block
+	ElseIfs []ElseIfBranch
+	Else    Node
+	Pos     lexer.Position
+}
+
+func (i IfStmt) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("if (%s) %s", i.Cond.String(), i.Then.String()))
+
+	for _, branch := range i.ElseIfs {
+		sb.WriteString(fmt.Sprintf(" elseif (%s) %s", branch.Cond.String(), branch.Body.String()))
+	}
+
+	if i.Else != nil {
+		sb.WriteString(fmt.Sprintf(" else %s", i.Else.String()))
+	}
+
+	return sb.String()
+}
+
+func (i IfStmt) Eval(env *Environment) (any, error) {
+	cond, err := i.Cond.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	truthy, ok := cond.(bool)
+
+	if !ok {
+		return nil, RuntimeError{Pos: i.Pos, Message: fmt.Sprintf("if condition must be a boolean, got %T", cond)}
+	}
+
+	if truthy {
+		return i.Then.Eval(env)
+	}
+
+	for _, branch := range i.ElseIfs {
+		cond, err := branch.Cond.Eval(env)
+
+		if err != nil {
+			return nil, err
+		}
+
+		truthy, ok := cond.(bool)
+
+		if !ok {
+			return nil, RuntimeError{Pos: i.Pos, Message: fmt.Sprintf("elseif condition must be a boolean, got %T", cond)}
+		}
+
+		if truthy {
+			return branch.Body.Eval(env)
+		}
+	}
+
+	if i.Else != nil {
+		return i.Else.Eval(env)
+	}
+
+	return nil, nil
+}
+
+// WhileStmt repeats Body while Cond evaluates to true.
+type WhileStmt struct {
+	Cond Node
+	Body Block
+	Pos  lexer.Position
+}
+
+func (w WhileStmt) String() string {
+	return fmt.Sprintf("while (%s) %s", w.Cond.String(), w.Body.String())
+}
+
+func (w WhileStmt) Eval(env *Environment) (any, error) {
+	for {
+		cond, err := w.Cond.Eval(env)
+
+		if err != nil {
+			return nil, err
+		}
+
+		truthy, ok := cond.(bool)
+
+		if !ok {
+			return nil, RuntimeError{Pos: w.Pos, Message: fmt.Sprintf("while condition must be a boolean, got %T", cond)}
+		}
+
+		if !truthy {
+			return nil, nil
+		}
+
+		if _, err := w.Body.Eval(env); err != nil {
+			if _, isBreak := err.(breakSignal); isBreak {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+	}
+}
+
+// ForStmt is a C-style `for (init; cond; post) body` loop. Init and Post
+// may be nil, and Init is scoped to the loop alone.
+type ForStmt struct {
+	Init Node
+	Cond Node
+	Post Node
+	Body Block
+	Pos  lexer.Position
+}
+
+func (f ForStmt) String() string {
+	init, cond, post := "", "", ""
+
+	if f.Init != nil {
+		init = f.Init.String()
+	}
+
+	if f.Cond != nil {
+		cond = f.Cond.String()
+	}
+
+	if f.Post != nil {
+		post = f.Post.String()
+	}
+
+	return fmt.Sprintf("for (%s %s; %s) %s", init, cond, post, f.Body.String())
+}
+
+func (f ForStmt) Eval(env *Environment) (any, error) {
+	scope := NewEnclosedEnvironment(env)
+
+	if f.Init != nil {
+		if _, err := f.Init.Eval(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	for {
+		if f.Cond != nil {
+			cond, err := f.Cond.Eval(scope)
+
+			if err != nil {
+				return nil, err
+			}
+
+			truthy, ok := cond.(bool)
+
+			if !ok {
+				return nil, RuntimeError{Pos: f.Pos, Message: fmt.Sprintf("for condition must be a boolean, got %T", cond)}
+			}
+
+			if !truthy {
+				return nil, nil
+			}
+		}
+
+		if _, err := f.Body.Eval(scope); err != nil {
+			if _, isBreak := err.(breakSignal); isBreak {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		if f.Post != nil {
+			if _, err := f.Post.Eval(scope); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// FunctionDecl declares a named function: `function name(params) { body }`.
+// Evaluating it registers a Closure over the function in the current scope,
+// so the function body resolves free variables against the scope it was
+// declared in rather than whatever scope it's later called from.
+type FunctionDecl struct {
+	Name   string
+	Params []string
+	Body   Block
+	Pos    lexer.Position
+}
+
+func (f FunctionDecl) String() string {
+	return fmt.Sprintf("function %s(%s) %s", f.Name, strings.Join(f.Params, ", "), f.Body.String())
+}
+
+func (f FunctionDecl) Eval(env *Environment) (any, error) {
+	env.Define(f.Name, Closure{Decl: f, Env: env})
+	return nil, nil
+}
+
+// Call invokes the function body with args bound to its parameters in a
+// scope enclosed by closureEnv, returning the value of its ReturnStmt (or
+// nil if the body runs off the end without returning).
+func (f FunctionDecl) Call(closureEnv *Environment, args []any) (any, error) {
+	if len(args) != len(f.Params) {
+		return nil, RuntimeError{Pos: f.Pos, Message: fmt.Sprintf("%s expects %d argument(s), got %d", f.Name, len(f.Params), len(args))}
+	}
+
+	scope := NewEnclosedEnvironment(closureEnv)
+
+	for i, param := range f.Params {
+		scope.Define(param, args[i])
+	}
+
+	_, err := f.Body.Eval(scope)
+
+	if err == nil {
+		return nil, nil
+	}
+
+	if ret, isReturn := err.(returnSignal); isReturn {
+		return ret.value, nil
+	}
+
+	return nil, err
+}
+
+// Closure pairs a FunctionDecl with the environment it was declared in, so
+// a call resolves the function's free variables against its defining scope
+// rather than the caller's scope.
+type Closure struct {
+	Decl FunctionDecl
+	Env  *Environment
+}
+
+// Call invokes the wrapped FunctionDecl with its captured defining
+// environment as the enclosing scope.
+func (c Closure) Call(args []any) (any, error) {
+	return c.Decl.Call(c.Env, args)
+}
+
+// ReturnStmt exits the enclosing function with an optional value:
+// `return value;` or a bare `return;`.
+type ReturnStmt struct {
+	Value Node
+	Pos   lexer.Position
+}
+
+func (r ReturnStmt) String() string {
+	if r.Value == nil {
+		return "return;"
+	}
+
+	return fmt.Sprintf("return %s;", r.Value.String())
+}
+
+func (r ReturnStmt) Eval(env *Environment) (any, error) {
+	if r.Value == nil {
+		return nil, returnSignal{Pos: r.Pos}
+	}
+
+	value, err := r.Value.Eval(env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, returnSignal{value: value, Pos: r.Pos}
+}
+
+// Break exits the nearest enclosing WhileStmt or ForStmt: `break;`.
+type Break struct {
+	Pos lexer.Position
+}
+
+func (b Break) String() string {
+	return "break;"
+}
+
+func (b Break) Eval(env *Environment) (any, error) {
+	return nil, breakSignal{Pos: b.Pos}
+}
@@ -0,0 +1,323 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+func TestVarDeclAndAssign(t *testing.T) {
+	env := NewEnvironment()
+
+	decl := VarDecl{Name: "x", Value: Primary{10.0}}
+
+	if _, err := decl.Eval(env); err != nil {
+		t.Fatalf("unexpected error declaring variable: %s", err)
+	}
+
+	value, ok := env.Get("x")
+
+	if !ok || value != 10.0 {
+		t.Errorf("expected x to be bound to 10.0, got %#v", value)
+	}
+
+	assign := Assign{Name: "x", Value: Primary{20.0}}
+
+	if _, err := assign.Eval(env); err != nil {
+		t.Fatalf("unexpected error assigning variable: %s", err)
+	}
+
+	value, _ = env.Get("x")
+
+	if value != 20.0 {
+		t.Errorf("expected x to be reassigned to 20.0, got %#v", value)
+	}
+
+	if _, err := (Assign{Name: "undeclared", Value: Primary{1.0}}).Eval(env); err == nil {
+		t.Error("expected an error assigning to an undeclared variable")
+	}
+}
+
+// TestRuntimeErrorsCarryPosition checks that statement-level runtime errors
+// are wrapped in RuntimeError with the node's own Pos, rather than a plain
+// error that would print without a file:line:col prefix.
+func TestRuntimeErrorsCarryPosition(t *testing.T) {
+	pos := lexer.Position{Line: 7, Column: 3}
+	env := NewEnvironment()
+
+	_, err := (Assign{Name: "undeclared", Value: Primary{1.0}, Pos: pos}).Eval(env)
+	assertRuntimeErrorAt(t, "Assign", err, pos)
+
+	_, err = (IfStmt{Cond: Primary{1.0}, Then: Block{}, Pos: pos}).Eval(env)
+	assertRuntimeErrorAt(t, "IfStmt (then)", err, pos)
+
+	_, err = (IfStmt{
+		Cond:    Primary{false},
+		Then:    Block{},
+		ElseIfs: []ElseIfBranch{{Cond: Primary{1.0}, Body: Block{}}},
+		Pos:     pos,
+	}).Eval(env)
+	assertRuntimeErrorAt(t, "IfStmt (elseif)", err, pos)
+
+	_, err = (WhileStmt{Cond: Primary{1.0}, Body: Block{}, Pos: pos}).Eval(env)
+	assertRuntimeErrorAt(t, "WhileStmt", err, pos)
+
+	_, err = (ForStmt{Cond: Primary{1.0}, Body: Block{}, Pos: pos}).Eval(env)
+	assertRuntimeErrorAt(t, "ForStmt", err, pos)
+
+	_, err = (FunctionDecl{Name: "f", Params: []string{"a"}, Body: Block{}, Pos: pos}).Call(env, nil)
+	assertRuntimeErrorAt(t, "FunctionDecl.Call", err, pos)
+}
+
+func assertRuntimeErrorAt(t *testing.T, label string, err error, pos lexer.Position) {
+	t.Helper()
+
+	runtimeErr, ok := err.(RuntimeError)
+
+	if !ok {
+		t.Errorf("%s: expected a RuntimeError, got %#v", label, err)
+		return
+	}
+
+	if runtimeErr.Pos != pos {
+		t.Errorf("%s: expected RuntimeError.Pos %#v, got %#v", label, pos, runtimeErr.Pos)
+	}
+}
+
+func TestIfStmtBranches(t *testing.T) {
+	env := NewEnvironment()
+
+	ifStmt := IfStmt{
+		Cond: Primary{true},
+		Then: Block{Statements: []Node{ExprStmt{Expr: Primary{"then"}}}},
+		Else: Block{Statements: []Node{ExprStmt{Expr: Primary{"else"}}}},
+	}
+
+	value, err := ifStmt.Eval(env)
+
+	if err != nil || value != "then" {
+		t.Errorf("expected truthy condition to run the then branch, got %#v (err: %v)", value, err)
+	}
+
+	ifStmt.Cond = Primary{false}
+	value, err = ifStmt.Eval(env)
+
+	if err != nil || value != "else" {
+		t.Errorf("expected falsy condition to run the else branch, got %#v (err: %v)", value, err)
+	}
+}
+
+func TestIfStmtElseIfBranches(t *testing.T) {
+	env := NewEnvironment()
+
+	ifStmt := IfStmt{
+		Cond: Primary{false},
+		Then: Block{Statements: []Node{ExprStmt{Expr: Primary{"then"}}}},
+		ElseIfs: []ElseIfBranch{
+			{Cond: Primary{false}, Body: Block{Statements: []Node{ExprStmt{Expr: Primary{"elseif-1"}}}}},
+			{Cond: Primary{true}, Body: Block{Statements: []Node{ExprStmt{Expr: Primary{"elseif-2"}}}}},
+		},
+		Else: Block{Statements: []Node{ExprStmt{Expr: Primary{"else"}}}},
+	}
+
+	value, err := ifStmt.Eval(env)
+
+	if err != nil || value != "elseif-2" {
+		t.Errorf("expected the first truthy elseif branch to run, got %#v (err: %v)", value, err)
+	}
+
+	ifStmt.ElseIfs[1].Cond = Primary{false}
+	value, err = ifStmt.Eval(env)
+
+	if err != nil || value != "else" {
+		t.Errorf("expected to fall through to else when no elseif matches, got %#v (err: %v)", value, err)
+	}
+}
+
+func TestWhileStmtHonorsBreak(t *testing.T) {
+	env := NewEnvironment()
+	env.Define("i", 0.0)
+
+	loop := WhileStmt{
+		Cond: Binary{Identifier{name: "i"}, lexer.NewToken(lexer.Less, lexer.Less.Lexeme(), 1), Primary{3.0}},
+		Body: Block{Statements: []Node{
+			Assign{Name: "i", Value: Binary{Identifier{name: "i"}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Primary{1.0}}},
+			Break{},
+		}},
+	}
+
+	if _, err := loop.Eval(env); err != nil {
+		t.Fatalf("unexpected error evaluating while loop: %s", err)
+	}
+
+	value, _ := env.Get("i")
+
+	if value != 1.0 {
+		t.Errorf("expected break to stop the loop after one iteration, got i = %#v", value)
+	}
+}
+
+func TestFunctionDeclCall(t *testing.T) {
+	env := NewEnvironment()
+
+	decl := FunctionDecl{
+		Name:   "double",
+		Params: []string{"n"},
+		Body: Block{Statements: []Node{
+			ReturnStmt{Value: Binary{Identifier{name: "n"}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Identifier{name: "n"}}},
+		}},
+	}
+
+	if _, err := decl.Eval(env); err != nil {
+		t.Fatalf("unexpected error declaring function: %s", err)
+	}
+
+	result, err := decl.Call(env, []any{21.0})
+
+	if err != nil {
+		t.Fatalf("unexpected error calling function: %s", err)
+	}
+
+	if result != 42.0 {
+		t.Errorf("expected double(21.0) to return 42.0, got %#v", result)
+	}
+}
+
+// evalProgram lexes, parses and evaluates source against a fresh global
+// environment, for tests that need a full program rather than hand-built
+// nodes.
+func evalProgram(t *testing.T, source string) any {
+	t.Helper()
+
+	lx := lexer.New(source)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	program, parseErrors := NewParser(tokens).Parse()
+
+	if len(parseErrors) != 0 {
+		t.Fatalf("unexpected parse errors: %#v", parseErrors)
+	}
+
+	value, err := program.Eval(NewGlobalEnvironment())
+
+	if err != nil {
+		t.Fatalf("unexpected error evaluating program: %s", err)
+	}
+
+	return value
+}
+
+// TestClosureResolvesDefiningScopeNotCallSite proves functions are lexically
+// scoped: a shadowed binding in the caller's scope must not leak into a
+// function declared somewhere else, even though the caller's environment is
+// reachable when the call happens.
+func TestClosureResolvesDefiningScopeNotCallSite(t *testing.T) {
+	source := `
+		var x = "global";
+
+		function show() {
+			return x;
+		}
+
+		function wrapper() {
+			var x = "shadowed";
+			return show();
+		}
+
+		wrapper();
+	`
+
+	if value := evalProgram(t, source); value != "global" {
+		t.Errorf("expected show() to resolve x from its defining scope, got %#v", value)
+	}
+}
+
+// TestClosureReturnedFromFunctionCapturesParams proves a function returned
+// from another function keeps access to the params of the call that created
+// it, even once that call has returned.
+func TestClosureReturnedFromFunctionCapturesParams(t *testing.T) {
+	source := `
+		function makeAdder(x) {
+			function add(y) {
+				return x + y;
+			}
+
+			return add;
+		}
+
+		var addFive = makeAdder(5);
+		addFive(3);
+	`
+
+	if value := evalProgram(t, source); value != int64(8) {
+		t.Errorf("expected addFive(3) to return 8, got %#v", value)
+	}
+}
+
+// TestForLoopPostClauseReportsRealPosition checks that an error raised by
+// the post-clause of a for loop carries the post-clause's own position
+// rather than the lexer.Position zero value (line 0, column 0).
+func TestForLoopPostClauseReportsRealPosition(t *testing.T) {
+	lx := lexer.New("for (var i = 0; i < 1; missing = missing + 1) { }")
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) != 0 {
+		t.Fatalf("unexpected lexer errors: %#v", lexErrors)
+	}
+
+	program, parseErrors := NewParser(tokens).Parse()
+
+	if len(parseErrors) != 0 {
+		t.Fatalf("unexpected parse errors: %#v", parseErrors)
+	}
+
+	_, err := program.Eval(NewEnvironment())
+
+	runtimeErr, ok := err.(RuntimeError)
+
+	if !ok {
+		t.Fatalf("expected a RuntimeError, got %#v", err)
+	}
+
+	if runtimeErr.Pos.Line == 0 && runtimeErr.Pos.Column == 0 {
+		t.Errorf("expected the post-clause error to carry a real position, got %#v", runtimeErr.Pos)
+	}
+}
+
+// TestBreakOutsideLoopReportsPosition checks that a misplaced `break;` at
+// top level carries its own position in the error message, like every
+// other runtime error, instead of a bare "break outside of a loop".
+func TestBreakOutsideLoopReportsPosition(t *testing.T) {
+	pos := lexer.Position{Line: 3, Column: 1}
+
+	_, err := (Break{Pos: pos}).Eval(NewEnvironment())
+
+	if err == nil {
+		t.Fatal("expected an error evaluating break outside of a loop")
+	}
+
+	if !strings.Contains(err.Error(), pos.String()) {
+		t.Errorf("expected the error to mention %s, got %q", pos.String(), err.Error())
+	}
+}
+
+// TestReturnOutsideFunctionReportsPosition checks that a misplaced
+// `return;` at top level carries its own position in the error message.
+func TestReturnOutsideFunctionReportsPosition(t *testing.T) {
+	pos := lexer.Position{Line: 5, Column: 2}
+
+	_, err := (ReturnStmt{Pos: pos}).Eval(NewEnvironment())
+
+	if err == nil {
+		t.Fatal("expected an error evaluating return outside of a function")
+	}
+
+	if !strings.Contains(err.Error(), pos.String()) {
+		t.Errorf("expected the error to mention %s, got %q", pos.String(), err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+package ast
+
+import "fmt"
+
+// Visitor receives a callback for every node kind Walk can reach,
+// letting external tooling (pretty-printers, constant-folding passes,
+// static analyzers) inspect a parsed tree without depending on the
+// unexported fields of the ast package.
+type Visitor interface {
+	VisitProgram(Program)
+	VisitPrimary(Primary)
+	VisitIdentifier(Identifier)
+	VisitCallExpression(CallExpression)
+	VisitUnary(Unary)
+	VisitBinary(Binary)
+	VisitTernary(Ternary)
+	VisitVarDecl(VarDecl)
+	VisitAssign(Assign)
+	VisitExprStmt(ExprStmt)
+	VisitPrintStmt(PrintStmt)
+	VisitBlock(Block)
+	VisitIfStmt(IfStmt)
+	VisitWhileStmt(WhileStmt)
+	VisitForStmt(ForStmt)
+	VisitFunctionDecl(FunctionDecl)
+	VisitReturnStmt(ReturnStmt)
+	VisitBreak(Break)
+}
+
+// Walk visits n, then descends into its children in evaluation order. It
+// panics on a Node type it doesn't know how to walk, since that means
+// the ast package grew a node kind without updating Visitor and Walk
+// together.
+func Walk(v Visitor, n Node) {
+	if n == nil {
+		return
+	}
+
+	switch node := n.(type) {
+	case Program:
+		v.VisitProgram(node)
+
+		for _, stmt := range node.Statements {
+			Walk(v, stmt)
+		}
+	case Primary:
+		v.VisitPrimary(node)
+
+		if nested, ok := node.value.(Node); ok {
+			Walk(v, nested)
+		}
+	case Identifier:
+		v.VisitIdentifier(node)
+	case CallExpression:
+		v.VisitCallExpression(node)
+		Walk(v, node.callee)
+
+		for _, arg := range node.args {
+			Walk(v, arg)
+		}
+	case Unary:
+		v.VisitUnary(node)
+		Walk(v, node.node)
+	case Binary:
+		v.VisitBinary(node)
+		Walk(v, node.left)
+		Walk(v, node.right)
+	case Ternary:
+		v.VisitTernary(node)
+		Walk(v, node.cond)
+		Walk(v, node.then)
+		Walk(v, node.els)
+	case VarDecl:
+		v.VisitVarDecl(node)
+		Walk(v, node.Value)
+	case Assign:
+		v.VisitAssign(node)
+		Walk(v, node.Value)
+	case ExprStmt:
+		v.VisitExprStmt(node)
+		Walk(v, node.Expr)
+	case PrintStmt:
+		v.VisitPrintStmt(node)
+		Walk(v, node.Expr)
+	case Block:
+		v.VisitBlock(node)
+
+		for _, stmt := range node.Statements {
+			Walk(v, stmt)
+		}
+	case IfStmt:
+		v.VisitIfStmt(node)
+		Walk(v, node.Cond)
+		Walk(v, node.Then)
+
+		for _, branch := range node.ElseIfs {
+			Walk(v, branch.Cond)
+			Walk(v, branch.Body)
+		}
+
+		Walk(v, node.Else)
+	case WhileStmt:
+		v.VisitWhileStmt(node)
+		Walk(v, node.Cond)
+		Walk(v, node.Body)
+	case ForStmt:
+		v.VisitForStmt(node)
+		Walk(v, node.Init)
+		Walk(v, node.Cond)
+		Walk(v, node.Post)
+		Walk(v, node.Body)
+	case FunctionDecl:
+		v.VisitFunctionDecl(node)
+		Walk(v, node.Body)
+	case ReturnStmt:
+		v.VisitReturnStmt(node)
+		Walk(v, node.Value)
+	case Break:
+		v.VisitBreak(node)
+	default:
+		panic(fmt.Sprintf("ast.Walk: unsupported node type %T", n))
+	}
+}
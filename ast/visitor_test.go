@@ -0,0 +1,104 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+// countingVisitor counts how many Binary and Identifier nodes are
+// visited, leaving every other method as a no-op.
+type countingVisitor struct {
+	binaries    int
+	identifiers int
+}
+
+func (countingVisitor) VisitProgram(Program)               {}
+func (countingVisitor) VisitPrimary(Primary)               {}
+func (c *countingVisitor) VisitIdentifier(Identifier)      { c.identifiers++ }
+func (countingVisitor) VisitCallExpression(CallExpression) {}
+func (countingVisitor) VisitUnary(Unary)                   {}
+func (c *countingVisitor) VisitBinary(Binary)              { c.binaries++ }
+func (countingVisitor) VisitTernary(Ternary)               {}
+func (countingVisitor) VisitVarDecl(VarDecl)               {}
+func (countingVisitor) VisitAssign(Assign)                 {}
+func (countingVisitor) VisitExprStmt(ExprStmt)             {}
+func (countingVisitor) VisitPrintStmt(PrintStmt)           {}
+func (countingVisitor) VisitBlock(Block)                   {}
+func (countingVisitor) VisitIfStmt(IfStmt)                 {}
+func (countingVisitor) VisitWhileStmt(WhileStmt)           {}
+func (countingVisitor) VisitForStmt(ForStmt)               {}
+func (countingVisitor) VisitFunctionDecl(FunctionDecl)     {}
+func (countingVisitor) VisitReturnStmt(ReturnStmt)         {}
+func (countingVisitor) VisitBreak(Break)                   {}
+
+func TestWalkDescendsBinaryOperands(t *testing.T) {
+	expr := Binary{
+		left:  Binary{Identifier{name: "x"}, lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1), Identifier{name: "y"}},
+		op:    lexer.NewToken(lexer.Star, lexer.Star.Lexeme(), 1),
+		right: Identifier{name: "z"},
+	}
+
+	counts := &countingVisitor{}
+	Walk(counts, expr)
+
+	if counts.binaries != 2 {
+		t.Errorf("expected Walk to visit 2 binary nodes, got %d", counts.binaries)
+	}
+
+	if counts.identifiers != 3 {
+		t.Errorf("expected Walk to visit 3 identifiers, got %d", counts.identifiers)
+	}
+}
+
+func TestWalkDescendsElseIfBranches(t *testing.T) {
+	ifStmt := IfStmt{
+		Cond: Identifier{name: "a"},
+		Then: Block{Statements: []Node{Identifier{name: "then"}}},
+		ElseIfs: []ElseIfBranch{
+			{Cond: Identifier{name: "b"}, Body: Block{Statements: []Node{Identifier{name: "elseif"}}}},
+		},
+		Else: Block{Statements: []Node{Identifier{name: "else"}}},
+	}
+
+	counts := &countingVisitor{}
+	Walk(counts, ifStmt)
+
+	if counts.identifiers != 5 {
+		t.Errorf("expected Walk to visit 5 identifiers across cond, then, elseif and else, got %d", counts.identifiers)
+	}
+}
+
+func TestNodeAccessors(t *testing.T) {
+	binary := Binary{
+		left:  Primary{int64(1)},
+		op:    lexer.NewToken(lexer.Plus, lexer.Plus.Lexeme(), 1),
+		right: Primary{int64(2)},
+	}
+
+	if binary.Left() != (Primary{int64(1)}) {
+		t.Errorf("expected Left() to return the left operand, got %#v", binary.Left())
+	}
+
+	if binary.Op().Kind() != lexer.Plus {
+		t.Errorf("expected Op() to return the Plus token, got %#v", binary.Op())
+	}
+
+	if binary.Right() != (Primary{int64(2)}) {
+		t.Errorf("expected Right() to return the right operand, got %#v", binary.Right())
+	}
+
+	unary := Unary{lexer.NewToken(lexer.Minus, lexer.Minus.Lexeme(), 1), Primary{int64(1)}}
+
+	if unary.Op().Kind() != lexer.Minus {
+		t.Errorf("expected Op() to return the Minus token, got %#v", unary.Op())
+	}
+
+	if unary.Operand() != (Primary{int64(1)}) {
+		t.Errorf("expected Operand() to return the operand, got %#v", unary.Operand())
+	}
+
+	if (Primary{"value"}).Value() != "value" {
+		t.Errorf("expected Value() to return the wrapped literal")
+	}
+}
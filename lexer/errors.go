@@ -0,0 +1,35 @@
+package lexer
+
+import "fmt"
+
+// UnterminatedStringError reports a string literal that was never closed
+// before the end of input.
+type UnterminatedStringError struct {
+	Pos Position
+}
+
+func (e UnterminatedStringError) Error() string {
+	return fmt.Sprintf("%s: unterminated string", e.Pos)
+}
+
+// UnexpectedRuneError reports a rune the lexer doesn't know how to start
+// a token with.
+type UnexpectedRuneError struct {
+	Rune rune
+	Pos  Position
+}
+
+func (e UnexpectedRuneError) Error() string {
+	return fmt.Sprintf("%s: unexpected token %q", e.Pos, e.Rune)
+}
+
+// InvalidNumberError reports a numeric lexeme that could not be parsed as
+// a number.
+type InvalidNumberError struct {
+	Lexeme string
+	Pos    Position
+}
+
+func (e InvalidNumberError) Error() string {
+	return fmt.Sprintf("%s: invalid number %q", e.Pos, e.Lexeme)
+}
@@ -1,34 +1,47 @@
 package lexer
 
 import (
-	"errors"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
 type Lexer struct {
+	file    string
 	source  []rune
 	tokens  []Token
-	errors  []string // TODO: should be custom errors i think
+	errors  []error
 	start   int
 	current int
 	line    int
+	column  int
 }
 
+// New creates a Lexer over source with no associated file name, as used
+// for REPL input.
 func New(source string) Lexer {
+	return NewFile("", source)
+}
+
+// NewFile creates a Lexer over source, tagging every token's Position
+// with file so diagnostics can point back at it.
+func NewFile(file, source string) Lexer {
 	return Lexer{
+		file:    file,
 		source:  []rune(source),
 		tokens:  []Token{},
-		errors:  []string{},
+		errors:  []error{},
 		start:   0,
 		current: 0,
 		line:    1,
+		column:  1,
 	}
 }
 
-func (l *Lexer) ScanTokens() ([]Token, []string) {
+func (l *Lexer) ScanTokens() ([]Token, []error) {
 	for !l.isEnd() {
 		var token Token
+		startLine, startColumn := l.line, l.column
 		ch := l.advance()
 
 		if unicode.IsSpace(ch) {
@@ -37,98 +50,133 @@ func (l *Lexer) ScanTokens() ([]Token, []string) {
 		}
 
 		if ch == '"' {
-			lexeme, err := l.parseString()
+			lexeme, err := l.parseString(startLine, startColumn)
 
 			if err != nil {
-				l.errors = append(l.errors, err.Error())
+				l.errors = append(l.errors, err)
+				l.tokens = append(l.tokens, newErrorToken(err, Position{l.file, startLine, startColumn, 1}))
 				continue
 			}
 
-			token = NewToken(String, lexeme, l.line)
+			token = l.newToken(String, lexeme, startLine, startColumn)
 			l.tokens = append(l.tokens, token)
 			continue
 		}
 
 		if unicode.IsNumber(ch) {
-			lexeme := l.parseNumber()
-			token = NewToken(Number, lexeme, l.line)
+			lexeme, isFloat := l.parseNumber()
+			kind := Integer
+
+			if isFloat {
+				kind = Float
+			}
+
+			if _, err := strconv.ParseFloat(lexeme, 64); err != nil {
+				numErr := InvalidNumberError{Lexeme: lexeme, Pos: Position{l.file, startLine, startColumn, len([]rune(lexeme))}}
+				l.errors = append(l.errors, numErr)
+				l.tokens = append(l.tokens, newErrorToken(numErr, numErr.Pos))
+				continue
+			}
+
+			token = l.newToken(kind, lexeme, startLine, startColumn)
 			l.tokens = append(l.tokens, token)
 			continue
 		}
 
 		if unicode.IsLetter(ch) {
 			lexeme, kind := l.parseKeywordOrIdentifier()
-			token = NewToken(kind, lexeme, l.line)
+			token = l.newToken(kind, lexeme, startLine, startColumn)
 			l.tokens = append(l.tokens, token)
 			continue
 		}
 
 		switch ch {
 		case LParen.Rune():
-			token = NewToken(LParen, LParen.Lexeme(), l.line)
+			token = l.newToken(LParen, LParen.Lexeme(), startLine, startColumn)
 		case RParen.Rune():
-			token = NewToken(RParen, RParen.Lexeme(), l.line)
+			token = l.newToken(RParen, RParen.Lexeme(), startLine, startColumn)
 		case LBrace.Rune():
-			token = NewToken(LBrace, LBrace.Lexeme(), l.line)
+			token = l.newToken(LBrace, LBrace.Lexeme(), startLine, startColumn)
 		case RBrace.Rune():
-			token = NewToken(RBrace, RBrace.Lexeme(), l.line)
+			token = l.newToken(RBrace, RBrace.Lexeme(), startLine, startColumn)
+		case Colon.Rune():
+			token = l.newToken(Colon, Colon.Lexeme(), startLine, startColumn)
 		case Comma.Rune():
-			token = NewToken(Comma, Comma.Lexeme(), l.line)
+			token = l.newToken(Comma, Comma.Lexeme(), startLine, startColumn)
 		case Dot.Rune():
-			token = NewToken(Dot, Dot.Lexeme(), l.line)
+			token = l.newToken(Dot, Dot.Lexeme(), startLine, startColumn)
 		case Minus.Rune():
-			token = NewToken(Minus, Minus.Lexeme(), l.line)
+			token = l.newToken(Minus, Minus.Lexeme(), startLine, startColumn)
 		case Plus.Rune():
-			token = NewToken(Plus, Plus.Lexeme(), l.line)
+			token = l.newToken(Plus, Plus.Lexeme(), startLine, startColumn)
+		case Question.Rune():
+			token = l.newToken(Question, Question.Lexeme(), startLine, startColumn)
 		case Semicolon.Rune():
-			token = NewToken(Semicolon, Semicolon.Lexeme(), l.line)
+			token = l.newToken(Semicolon, Semicolon.Lexeme(), startLine, startColumn)
 		case Slash.Rune():
-			token = NewToken(Slash, Slash.Lexeme(), l.line)
+			token = l.newToken(Slash, Slash.Lexeme(), startLine, startColumn)
 		case Star.Rune():
-			token = NewToken(Star, Star.Lexeme(), l.line)
+			token = l.newToken(Star, Star.Lexeme(), startLine, startColumn)
 		case Bang.Rune():
 			if l.match(Eq.Rune()) {
-				token = NewToken(BangEq, BangEq.Lexeme(), l.line)
+				token = l.newToken(BangEq, BangEq.Lexeme(), startLine, startColumn)
 			} else {
-				token = NewToken(Bang, Bang.Lexeme(), l.line)
+				token = l.newToken(Bang, Bang.Lexeme(), startLine, startColumn)
 			}
 		case Eq.Rune():
 			if l.match(Eq.Rune()) {
-				token = NewToken(DoubleEq, DoubleEq.Lexeme(), l.line)
+				token = l.newToken(DoubleEq, DoubleEq.Lexeme(), startLine, startColumn)
 			} else {
-				token = NewToken(Eq, Eq.Lexeme(), l.line)
+				token = l.newToken(Eq, Eq.Lexeme(), startLine, startColumn)
 			}
 		case Greater.Rune():
 			if l.match(Eq.Rune()) {
-				token = NewToken(GreaterEq, GreaterEq.Lexeme(), l.line)
+				token = l.newToken(GreaterEq, GreaterEq.Lexeme(), startLine, startColumn)
 			} else {
-				token = NewToken(Greater, Greater.Lexeme(), l.line)
+				token = l.newToken(Greater, Greater.Lexeme(), startLine, startColumn)
 			}
 		case Less.Rune():
 			if l.match(Eq.Rune()) {
-				token = NewToken(LessEq, LessEq.Lexeme(), l.line)
+				token = l.newToken(LessEq, LessEq.Lexeme(), startLine, startColumn)
 			} else {
-				token = NewToken(Less, Less.Lexeme(), l.line)
+				token = l.newToken(Less, Less.Lexeme(), startLine, startColumn)
 			}
 		default:
-			l.errors = append(l.errors, "Unexpected token")
+			err := UnexpectedRuneError{Rune: ch, Pos: Position{l.file, startLine, startColumn, 1}}
+			l.errors = append(l.errors, err)
+			l.tokens = append(l.tokens, newErrorToken(err, err.Pos))
 			continue
 		}
 		l.tokens = append(l.tokens, token)
 	}
 
 	l.increaseLine()
-	eof := NewToken(EOF, "", l.line)
+	eof := l.newToken(EOF, "", l.line, l.column)
 	l.tokens = append(l.tokens, eof)
 
 	return l.tokens, l.errors
 }
 
+func (l *Lexer) newToken(kind TokenKind, lexeme string, line, column int) Token {
+	return newPositionedToken(kind, lexeme, Position{
+		File:   l.file,
+		Line:   line,
+		Column: column,
+		Width:  len([]rune(lexeme)),
+	})
+}
+
 func (l *Lexer) advance() rune {
 	ch := l.source[l.current]
 	l.start = l.current
 	l.current++
 
+	if ch == '\n' {
+		l.increaseLine()
+	} else {
+		l.column++
+	}
+
 	return ch
 }
 
@@ -169,29 +217,16 @@ func (l *Lexer) isEnd() bool {
 
 func (l *Lexer) increaseLine() {
 	l.line++
+	l.column = 1
 }
 
 func (l *Lexer) consumeSpaces() {
-	if l.peek() == '\n' {
-		l.increaseLine()
-	}
-
-	for !l.isEnd() {
-		ch := l.lookahead()
-
-		if !unicode.IsSpace(ch) {
-			break
-		}
-
-		if ch == '\n' {
-			l.increaseLine()
-		}
-
+	for !l.isEnd() && unicode.IsSpace(l.lookahead()) {
 		l.advance()
 	}
 }
 
-func (l *Lexer) parseString() (string, error) {
+func (l *Lexer) parseString(startLine, startColumn int) (string, error) {
 	var lexeme strings.Builder
 
 	for !l.isEnd() {
@@ -204,10 +239,12 @@ func (l *Lexer) parseString() (string, error) {
 		lexeme.WriteRune(ch)
 	}
 
-	return "", errors.New("unterminated string")
+	return "", UnterminatedStringError{Pos: Position{l.file, startLine, startColumn, 1}}
 }
 
-func (l *Lexer) parseNumber() string {
+// parseNumber consumes a numeric lexeme and reports whether it contains a
+// decimal point, so the caller can emit a Float or Integer token.
+func (l *Lexer) parseNumber() (string, bool) {
 	var lexeme strings.Builder
 	lexeme.WriteRune(l.peek())
 	isFloat := false
@@ -236,7 +273,7 @@ func (l *Lexer) parseNumber() string {
 		l.advance()
 	}
 
-	return lexeme.String()
+	return lexeme.String(), isFloat
 }
 
 func (l *Lexer) parseKeywordOrIdentifier() (string, TokenKind) {
@@ -3,38 +3,48 @@ package lexer
 import "testing"
 
 func TestScanTokens(t *testing.T) {
-	source := "()   {   },.-+;/*!!= ==\n   =<<=>>=  \n\"Hello world\"\n1234 12.25 .9\nor and function myVar"
+	source := "()   {   },.?:-+;/*!!= ==\n   =<<=>>=  \n\"Hello world\"\n1234 12.25 .9\nor and function myVar"
 	lexer := New(source)
+
+	// Tokens produced by the lexer carry a full Position (file, line and
+	// column), so expectations here are built with newPositionedToken
+	// instead of the line-only NewToken used by hand-rolled parser tests.
+	token := func(kind TokenKind, lexeme string, line, column int) Token {
+		return newPositionedToken(kind, lexeme, Position{Line: line, Column: column, Width: len(lexeme)})
+	}
+
 	expectedTokens := []Token{
-		NewToken(LParen, LParen.Lexeme(), 1),
-		NewToken(RParen, RParen.Lexeme(), 1),
-		NewToken(LBrace, LBrace.Lexeme(), 1),
-		NewToken(RBrace, RBrace.Lexeme(), 1),
-		NewToken(Comma, Comma.Lexeme(), 1),
-		NewToken(Dot, Dot.Lexeme(), 1),
-		NewToken(Minus, Minus.Lexeme(), 1),
-		NewToken(Plus, Plus.Lexeme(), 1),
-		NewToken(Semicolon, Semicolon.Lexeme(), 1),
-		NewToken(Slash, Slash.Lexeme(), 1),
-		NewToken(Star, Star.Lexeme(), 1),
-		NewToken(Bang, Bang.Lexeme(), 1),
-		NewToken(BangEq, BangEq.Lexeme(), 1),
-		NewToken(DoubleEq, DoubleEq.Lexeme(), 1),
-		NewToken(Eq, Eq.Lexeme(), 2),
-		NewToken(Less, Less.Lexeme(), 2),
-		NewToken(LessEq, LessEq.Lexeme(), 2),
-		NewToken(Greater, Greater.Lexeme(), 2),
-		NewToken(GreaterEq, GreaterEq.Lexeme(), 2),
-		NewToken(String, "Hello world", 3),
-		NewToken(Number, "1234", 4),
-		NewToken(Number, "12.25", 4),
-		NewToken(Dot, Dot.Lexeme(), 4),
-		NewToken(Number, "9", 4),
-		NewToken(Or, Or.Lexeme(), 5),
-		NewToken(And, And.Lexeme(), 5),
-		NewToken(Function, Function.Lexeme(), 5),
-		NewToken(Identifier, "myVar", 5),
-		NewToken(EOF, "", 6),
+		token(LParen, LParen.Lexeme(), 1, 1),
+		token(RParen, RParen.Lexeme(), 1, 2),
+		token(LBrace, LBrace.Lexeme(), 1, 6),
+		token(RBrace, RBrace.Lexeme(), 1, 10),
+		token(Comma, Comma.Lexeme(), 1, 11),
+		token(Dot, Dot.Lexeme(), 1, 12),
+		token(Question, Question.Lexeme(), 1, 13),
+		token(Colon, Colon.Lexeme(), 1, 14),
+		token(Minus, Minus.Lexeme(), 1, 15),
+		token(Plus, Plus.Lexeme(), 1, 16),
+		token(Semicolon, Semicolon.Lexeme(), 1, 17),
+		token(Slash, Slash.Lexeme(), 1, 18),
+		token(Star, Star.Lexeme(), 1, 19),
+		token(Bang, Bang.Lexeme(), 1, 20),
+		token(BangEq, BangEq.Lexeme(), 1, 21),
+		token(DoubleEq, DoubleEq.Lexeme(), 1, 24),
+		token(Eq, Eq.Lexeme(), 2, 4),
+		token(Less, Less.Lexeme(), 2, 5),
+		token(LessEq, LessEq.Lexeme(), 2, 6),
+		token(Greater, Greater.Lexeme(), 2, 8),
+		token(GreaterEq, GreaterEq.Lexeme(), 2, 9),
+		token(String, "Hello world", 3, 1),
+		token(Integer, "1234", 4, 1),
+		token(Float, "12.25", 4, 6),
+		token(Dot, Dot.Lexeme(), 4, 12),
+		token(Integer, "9", 4, 13),
+		token(Or, Or.Lexeme(), 5, 1),
+		token(And, And.Lexeme(), 5, 4),
+		token(Function, Function.Lexeme(), 5, 8),
+		token(Identifier, "myVar", 5, 17),
+		token(EOF, "", 6, 1),
 	}
 
 	tokens, _ := lexer.ScanTokens()
@@ -51,3 +61,41 @@ func TestScanTokens(t *testing.T) {
 		}
 	}
 }
+
+func TestScanTokensReportsUnterminatedString(t *testing.T) {
+	lexer := New(`"unterminated`)
+	tokens, errs := lexer.ScanTokens()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected a single error, got %#v", errs)
+	}
+
+	if _, ok := errs[0].(UnterminatedStringError); !ok {
+		t.Errorf("expected an UnterminatedStringError, got %#v", errs[0])
+	}
+
+	if len(tokens) == 0 || tokens[0].Kind() != Err {
+		t.Fatalf("expected the first token to be an Err token, got %#v", tokens)
+	}
+
+	if tokens[0].Err() != errs[0] {
+		t.Errorf("expected the Err token to carry the same error, got %#v", tokens[0].Err())
+	}
+}
+
+func TestScanTokensReportsUnexpectedRune(t *testing.T) {
+	lexer := New("@")
+	tokens, errs := lexer.ScanTokens()
+
+	if len(errs) != 1 {
+		t.Fatalf("expected a single error, got %#v", errs)
+	}
+
+	if _, ok := errs[0].(UnexpectedRuneError); !ok {
+		t.Errorf("expected an UnexpectedRuneError, got %#v", errs[0])
+	}
+
+	if len(tokens) == 0 || tokens[0].Kind() != Err {
+		t.Fatalf("expected the first token to be an Err token, got %#v", tokens)
+	}
+}
@@ -0,0 +1,85 @@
+package lexer
+
+import "fmt"
+
+// Position pinpoints a span of source text for diagnostics: the file it
+// came from (empty for REPL input), its line and starting column (both
+// 1-indexed), and how many runes the span covers.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+	Width  int
+}
+
+func (p Position) String() string {
+	if p.File == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Underline picks the line of source that Position points into and
+// renders it with a caret line underneath marking the offending span, or
+// returns "" when Line is outside source, e.g.:
+//
+//	1 + ;
+//	    ^
+func (p Position) Underline(source string) string {
+	lines := splitLines(source)
+
+	if p.Line < 1 || p.Line > len(lines) {
+		return ""
+	}
+
+	line := lines[p.Line-1]
+	width := p.Width
+
+	if width < 1 {
+		width = 1
+	}
+
+	padding := p.Column - 1
+
+	if padding < 0 {
+		padding = 0
+	}
+
+	return fmt.Sprintf("%s\n%s%s", line, spaces(padding), carets(width))
+}
+
+func splitLines(source string) []string {
+	var lines []string
+	start := 0
+
+	for i, r := range source {
+		if r == '\n' {
+			lines = append(lines, source[start:i])
+			start = i + 1
+		}
+	}
+
+	lines = append(lines, source[start:])
+	return lines
+}
+
+func spaces(n int) string {
+	b := make([]byte, n)
+
+	for i := range b {
+		b[i] = ' '
+	}
+
+	return string(b)
+}
+
+func carets(n int) string {
+	b := make([]byte, n)
+
+	for i := range b {
+		b[i] = '^'
+	}
+
+	return string(b)
+}
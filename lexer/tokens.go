@@ -7,10 +7,12 @@ const (
 	RParen     TokenKind = "RParen"
 	LBrace     TokenKind = "LBrace"
 	RBrace     TokenKind = "RBrace"
+	Colon      TokenKind = "Colon"
 	Comma      TokenKind = "Comma"
 	Dot        TokenKind = "Dot"
 	Minus      TokenKind = "Minus"
 	Plus       TokenKind = "Plus"
+	Question   TokenKind = "Question"
 	Semicolon  TokenKind = "Semicolon"
 	Slash      TokenKind = "Slash"
 	Star       TokenKind = "Star"
@@ -27,8 +29,10 @@ const (
 	Float      TokenKind = "Float"
 	Identifier TokenKind = "Identifier"
 	And        TokenKind = "And"
+	Break      TokenKind = "Break"
 	Class      TokenKind = "Class"
 	Else       TokenKind = "Else"
+	Elseif     TokenKind = "Elseif"
 	False      TokenKind = "False"
 	Function   TokenKind = "Function"
 	For        TokenKind = "For"
@@ -43,6 +47,7 @@ const (
 	Var        TokenKind = "Var"
 	While      TokenKind = "While"
 	EOF        TokenKind = "EOF"
+	Err        TokenKind = "Err"
 )
 
 var lexemeToKindMap = map[string]TokenKind{
@@ -50,10 +55,12 @@ var lexemeToKindMap = map[string]TokenKind{
 	")":        RParen,
 	"{":        LBrace,
 	"}":        RBrace,
+	":":        Colon,
 	",":        Comma,
 	".":        Dot,
 	"-":        Minus,
 	"+":        Plus,
+	"?":        Question,
 	";":        Semicolon,
 	"/":        Slash,
 	"*":        Star,
@@ -66,8 +73,10 @@ var lexemeToKindMap = map[string]TokenKind{
 	"<":        Less,
 	"<=":       LessEq,
 	"and":      And,
+	"break":    Break,
 	"class":    Class,
 	"else":     Else,
+	"elseif":   Elseif,
 	"false":    False,
 	"function": Function,
 	"for":      For,
@@ -127,21 +136,48 @@ func (t TokenKind) Rune() rune {
 type Token struct {
 	kind   TokenKind
 	lexeme string
-	line   int
+	pos    Position
+	err    error
 }
 
+// NewToken builds a Token carrying only a line number, which is enough
+// for hand-built tokens in tests and for parser code that only ever
+// forwards tokens it was handed. Tokens produced by the lexer itself
+// carry a full Position instead, via newPositionedToken.
 func NewToken(kind TokenKind, lexeme string, line int) Token {
 	return Token{
-		kind,
-		lexeme,
-		line,
+		kind:   kind,
+		lexeme: lexeme,
+		pos:    Position{Line: line, Width: len(lexeme)},
 	}
 }
 
-func (t *Token) Kind() TokenKind {
+func newPositionedToken(kind TokenKind, lexeme string, pos Position) Token {
+	return Token{kind: kind, lexeme: lexeme, pos: pos}
+}
+
+// newErrorToken builds an Err token carrying the scanning error that
+// produced it, so the parser can stop gracefully as soon as it reaches it
+// instead of advancing past a gap in the token stream.
+func newErrorToken(err error, pos Position) Token {
+	return Token{kind: Err, pos: pos, err: err}
+}
+
+func (t Token) Kind() TokenKind {
 	return t.kind
 }
 
-func (t *Token) Lexeme() string {
+func (t Token) Lexeme() string {
 	return t.lexeme
 }
+
+// Pos returns the source position this token was scanned from.
+func (t Token) Pos() Position {
+	return t.pos
+}
+
+// Err returns the scanning error this token carries. It is nil unless
+// Kind is Err.
+func (t Token) Err() error {
+	return t.err
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+
+	"github.com/alfredoprograma/fredlang/repl"
 )
 
 func readFromFile(path string) (string, error) {
@@ -29,7 +31,7 @@ func run() {
 		fmt.Println(source)
 
 	} else {
-		panic("Implement REPL")
+		repl.Start(os.Stdin, os.Stdout)
 	}
 }
 
@@ -0,0 +1,189 @@
+// Package repl implements the interactive read-eval-print loop for fredlang.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/alfredoprograma/fredlang/ast"
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+const (
+	prompt           = ">> "
+	continuationText = ".. "
+)
+
+// operatorKinds are the token kinds which always expect a right-hand
+// operand, used to detect when a line should be continued instead of
+// evaluated right away.
+var operatorKinds = map[lexer.TokenKind]bool{
+	lexer.Plus:      true,
+	lexer.Minus:     true,
+	lexer.Star:      true,
+	lexer.Slash:     true,
+	lexer.DoubleEq:  true,
+	lexer.BangEq:    true,
+	lexer.Greater:   true,
+	lexer.GreaterEq: true,
+	lexer.Less:      true,
+	lexer.LessEq:    true,
+	lexer.And:       true,
+	lexer.Or:        true,
+}
+
+// Start runs the REPL, reading lines from in and writing prompts, results
+// and errors to out. Input spanning multiple lines (unmatched parens or
+// braces, or a trailing binary operator) is accumulated until it forms a
+// complete chunk before being lexed, parsed and evaluated. Every chunk is
+// evaluated in one global environment, so bindings persist across prompts
+// until :reset replaces it with a fresh one.
+func Start(in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	var buffer strings.Builder
+	env := ast.NewGlobalEnvironment()
+
+	for {
+		if buffer.Len() == 0 {
+			fmt.Fprint(out, prompt)
+		} else {
+			fmt.Fprint(out, continuationText)
+		}
+
+		if !scanner.Scan() {
+			return
+		}
+
+		line := scanner.Text()
+
+		switch strings.TrimSpace(line) {
+		case ":quit":
+			return
+		case ":reset":
+			buffer.Reset()
+			env = ast.NewGlobalEnvironment()
+			continue
+		}
+
+		if buffer.Len() == 0 {
+			if rest, ok := cutMeta(line, ":ast"); ok {
+				printAST(out, rest)
+				continue
+			}
+		}
+
+		buffer.WriteString(line)
+		buffer.WriteRune('\n')
+
+		source := buffer.String()
+		lx := lexer.New(source)
+		tokens, lexErrors := lx.ScanTokens()
+
+		if needsContinuation(tokens, lexErrors) {
+			continue
+		}
+
+		buffer.Reset()
+		evaluate(out, env, source)
+	}
+}
+
+// cutMeta reports whether line starts with the given meta-command and, if
+// so, returns the remaining source to evaluate.
+func cutMeta(line, command string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+
+	if trimmed == command {
+		return "", true
+	}
+
+	if rest, ok := strings.CutPrefix(trimmed, command+" "); ok {
+		return rest, true
+	}
+
+	return "", false
+}
+
+func printAST(out io.Writer, source string) {
+	program, errs := parse(source)
+
+	if len(errs) > 0 {
+		printErrors(out, errs)
+		return
+	}
+
+	fmt.Fprintln(out, program.String())
+}
+
+func evaluate(out io.Writer, env *ast.Environment, source string) {
+	program, errs := parse(source)
+
+	if len(errs) > 0 {
+		printErrors(out, errs)
+		return
+	}
+
+	value, err := program.Eval(env)
+
+	if err != nil {
+		fmt.Fprintf(out, "error: %s\n", err)
+		return
+	}
+
+	fmt.Fprintf(out, "%v\n", value)
+}
+
+func parse(source string) (ast.Program, []error) {
+	lx := lexer.New(source)
+	tokens, lexErrors := lx.ScanTokens()
+
+	if len(lexErrors) > 0 {
+		return ast.Program{}, lexErrors
+	}
+
+	parser := ast.NewParser(tokens)
+	return parser.Parse()
+}
+
+func printErrors(out io.Writer, errs []error) {
+	for _, err := range errs {
+		fmt.Fprintf(out, "error: %s\n", err)
+	}
+}
+
+// needsContinuation reports whether source so far is an incomplete chunk:
+// an unmatched opening paren or brace, or a trailing token that still
+// expects a right-hand operand.
+func needsContinuation(tokens []lexer.Token, lexErrors []error) bool {
+	if len(lexErrors) > 0 {
+		return false
+	}
+
+	parenDepth, braceDepth := 0, 0
+
+	for _, token := range tokens {
+		switch token.Kind() {
+		case lexer.LParen:
+			parenDepth++
+		case lexer.RParen:
+			parenDepth--
+		case lexer.LBrace:
+			braceDepth++
+		case lexer.RBrace:
+			braceDepth--
+		}
+	}
+
+	if parenDepth > 0 || braceDepth > 0 {
+		return true
+	}
+
+	if len(tokens) < 2 {
+		return false
+	}
+
+	last := tokens[len(tokens)-2] // skip the EOF token appended by the lexer
+	return operatorKinds[last.Kind()]
+}
@@ -0,0 +1,105 @@
+package repl
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alfredoprograma/fredlang/lexer"
+)
+
+func TestStartEvaluatesExpressions(t *testing.T) {
+	in := strings.NewReader("1 + 1;\n:quit\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if !strings.Contains(out.String(), "2") {
+		t.Errorf("expected the output to contain the evaluated result, got %q", out.String())
+	}
+}
+
+func TestStartPersistsBindingsAcrossPrompts(t *testing.T) {
+	in := strings.NewReader("var x = 1;\nx + 1;\n:quit\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if !strings.Contains(out.String(), "2") {
+		t.Errorf("expected x to stay bound across prompts, got %q", out.String())
+	}
+}
+
+func TestStartResetClearsBindings(t *testing.T) {
+	in := strings.NewReader("var x = 1;\n:reset\nx;\n:quit\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if !strings.Contains(out.String(), "undefined variable") {
+		t.Errorf("expected :reset to drop the x binding, got %q", out.String())
+	}
+}
+
+func TestStartQuitStopsBeforeReadingFurtherInput(t *testing.T) {
+	in := strings.NewReader(":quit\nvar x = 1;\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if strings.Contains(out.String(), "1") {
+		t.Errorf("expected :quit to stop the loop immediately, got %q", out.String())
+	}
+}
+
+func TestStartAstMetaCommandPrintsTheParsedProgram(t *testing.T) {
+	in := strings.NewReader(":ast 1 + 2;\n:quit\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if !strings.Contains(out.String(), "(1 + 2)") {
+		t.Errorf("expected :ast to print the stringified AST, got %q", out.String())
+	}
+}
+
+func TestStartMultilineContinuation(t *testing.T) {
+	in := strings.NewReader("1 +\n2;\n:quit\n")
+	var out bytes.Buffer
+
+	Start(in, &out)
+
+	if !strings.Contains(out.String(), continuationText) {
+		t.Errorf("expected the second line to be prompted as a continuation, got %q", out.String())
+	}
+
+	if !strings.Contains(out.String(), "3") {
+		t.Errorf("expected the completed multiline chunk to evaluate to 3, got %q", out.String())
+	}
+}
+
+func TestNeedsContinuation(t *testing.T) {
+	cases := []struct {
+		name     string
+		source   string
+		expected bool
+	}{
+		{"complete expression", "1 + 1;", false},
+		{"unmatched open paren", "(1 + 1", true},
+		{"unmatched open brace", "if (true) {", true},
+		{"trailing binary operator", "1 +", true},
+		{"trailing logical operator", "true and", true},
+		{"empty input", "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			lx := lexer.New(c.source)
+			tokens, lexErrors := lx.ScanTokens()
+
+			if got := needsContinuation(tokens, lexErrors); got != c.expected {
+				t.Errorf("needsContinuation(%q) = %v, expected %v", c.source, got, c.expected)
+			}
+		})
+	}
+}